@@ -0,0 +1,150 @@
+// aggregate.go
+
+package tables
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Aggregate is a summary of a column's values shown in the footer, see
+// SetColumnAggregate.
+type Aggregate int
+
+const (
+	AggNone  Aggregate = iota // No summary
+	AggSum                    // Total of the numbers
+	AggAvg                    // Mean of the numbers
+	AggCount                  // Number of non-empty cells
+	AggMin                    // Smallest number
+	AggMax                    // Largest number
+)
+
+// SetColumnAggregate shows a summary of column col in the footer, computed
+// over the data rows each time the table is rendered. A footer row is added
+// if the table has none; cells set with SetFooter, such as a "Total" label,
+// are kept in the other columns. AggNone removes the summary.
+//
+// Cells are read as plain numbers, ANSI sequences and surrounding spaces
+// ignored; other cells are skipped by every aggregate but AggCount. Sums,
+// minimums and maximums are written with as many decimals as the most
+// precise value, averages with two more. Empty columns sum and count to 0
+// and have no average, minimum or maximum. Only rows SetFilter keeps are
+// included, and the exporters write the footer without the summaries.
+//
+// Example:
+//
+//	t.SetFooter("Total").
+//	    SetColumnAggregate(2, tables.AggSum).
+//	    SetColumnAggregate(3, tables.AggAvg)
+func (t *Table) SetColumnAggregate(col int, agg Aggregate) *Table {
+	if !t.checkColumn(col) {
+		return t
+	}
+	if agg == AggNone {
+		delete(t.aggregates, col)
+		return t
+	}
+	if t.aggregates == nil {
+		t.aggregates = make(map[int]Aggregate)
+	}
+	t.aggregates[col] = agg
+	return t
+}
+
+// aggregated returns t itself, or with SetColumnAggregate a copy whose
+// footer holds the summaries.
+func (t *Table) aggregated() *Table {
+	if len(t.aggregates) == 0 {
+		return t
+	}
+
+	footer := make([][]byte, len(t.headers))
+	for col := range footer {
+		footer[col] = []byte{}
+		if col < len(t.footer) && t.footer[col] != nil {
+			footer[col] = t.footer[col]
+		}
+	}
+	rows := t.dataPositions()
+	for col, agg := range t.aggregates {
+		footer[col] = t.aggregate(rows, col, agg)
+	}
+
+	cp := *t
+	cp.aggregates = nil
+	cp.footer = footer
+	return &cp
+}
+
+// dataPositions returns the positions in rows of the data rows.
+func (t *Table) dataPositions() []int {
+	positions := make([]int, 0, len(t.rows))
+	for pos, kind := range t.rowKinds {
+		if kind == rowData {
+			positions = append(positions, pos)
+		}
+	}
+	return positions
+}
+
+// aggregate computes agg over column col of the data rows at positions.
+func (t *Table) aggregate(positions []int, col int, agg Aggregate) []byte {
+	var sum, lo, hi float64
+	count, numbers, decimals := 0, 0, 0
+	for _, pos := range positions {
+		if t.isAux(pos) {
+			continue // a section banner or group subtotal
+		}
+		row := t.rows[pos]
+		if spans := t.spansAt(pos); spans != nil && spans[col] != 1 {
+			continue // part of a cell spanning columns, such as a section
+		}
+		s := strings.TrimSpace(cellString(row, col))
+		if s == "" {
+			continue
+		}
+		count++
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		if dot := strings.IndexByte(s, '.'); dot >= 0 && !strings.ContainsAny(s, "eE") {
+			decimals = max(decimals, len(s)-dot-1)
+		}
+		if numbers == 0 || v < lo {
+			lo = v
+		}
+		if numbers == 0 || v > hi {
+			hi = v
+		}
+		sum += v
+		numbers++
+	}
+
+	format := func(v float64, prec int) []byte {
+		return strconv.AppendFloat(nil, v, 'f', prec, 64)
+	}
+	switch agg {
+	case AggSum:
+		return format(sum, decimals)
+	case AggCount:
+		return strconv.AppendInt(nil, int64(count), 10)
+	}
+	if numbers == 0 {
+		return []byte{}
+	}
+	switch agg {
+	case AggAvg:
+		// Round to two more decimals than the values, then drop trailing
+		// zeros: 1.5 rather than 1.50, 2 rather than 2.00.
+		scale := math.Pow10(decimals + 2)
+		return strconv.AppendFloat(nil, math.Round(sum/float64(numbers)*scale)/scale, 'f', -1, 64)
+	case AggMin:
+		return format(lo, decimals)
+	case AggMax:
+		return format(hi, decimals)
+	}
+	return []byte{}
+}
@@ -0,0 +1,254 @@
+// appearance.go
+
+package tables
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// AppearanceVersion is the version of the JSON schema Style and Theme are
+// written in. It only changes when a release can no longer read documents
+// of the previous version the same way; fields added in a compatible way
+// keep it, and readers ignore fields they don't know.
+const AppearanceVersion = 1
+
+// styleJSON is the JSON form of a Style, each border rune as a string of one
+// character:
+//
+//	{"version": 1, "topLeft": "┌", "topRight": "┐", ..., "rightTee": "┤"}
+type styleJSON struct {
+	Version     int    `json:"version"`
+	TopLeft     string `json:"topLeft"`
+	TopRight    string `json:"topRight"`
+	BottomLeft  string `json:"bottomLeft"`
+	BottomRight string `json:"bottomRight"`
+	Horizontal  string `json:"horizontal"`
+	Vertical    string `json:"vertical"`
+	Cross       string `json:"cross"`
+	TopTee      string `json:"topTee"`
+	BottomTee   string `json:"bottomTee"`
+	LeftTee     string `json:"leftTee"`
+	RightTee    string `json:"rightTee"`
+}
+
+// MarshalJSON writes the style as an object with the schema "version" and
+// one field per border position, named like the Style fields in lower camel
+// case ("topLeft", "horizontal", ...), holding its character as a string.
+func (s Style) MarshalJSON() ([]byte, error) {
+	return json.Marshal(styleJSON{
+		Version:     AppearanceVersion,
+		TopLeft:     string(s.TopLeft),
+		TopRight:    string(s.TopRight),
+		BottomLeft:  string(s.BottomLeft),
+		BottomRight: string(s.BottomRight),
+		Horizontal:  string(s.Horizontal),
+		Vertical:    string(s.Vertical),
+		Cross:       string(s.Cross),
+		TopTee:      string(s.TopTee),
+		BottomTee:   string(s.BottomTee),
+		LeftTee:     string(s.LeftTee),
+		RightTee:    string(s.RightTee),
+	})
+}
+
+// UnmarshalJSON reads a style written by MarshalJSON. Every position must
+// hold exactly one character and the result must pass Validate; a missing
+// version is taken as 1, and a newer one than AppearanceVersion is refused.
+// Errors wrap ErrInvalidStyle, and leave s unchanged.
+func (s *Style) UnmarshalJSON(data []byte) error {
+	var j styleJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidStyle, err)
+	}
+	if err := checkAppearanceVersion(j.Version); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidStyle, err)
+	}
+
+	var st Style
+	for _, f := range []struct {
+		dst  *rune
+		name string
+		text string
+	}{
+		{&st.TopLeft, "topLeft", j.TopLeft},
+		{&st.TopRight, "topRight", j.TopRight},
+		{&st.BottomLeft, "bottomLeft", j.BottomLeft},
+		{&st.BottomRight, "bottomRight", j.BottomRight},
+		{&st.Horizontal, "horizontal", j.Horizontal},
+		{&st.Vertical, "vertical", j.Vertical},
+		{&st.Cross, "cross", j.Cross},
+		{&st.TopTee, "topTee", j.TopTee},
+		{&st.BottomTee, "bottomTee", j.BottomTee},
+		{&st.LeftTee, "leftTee", j.LeftTee},
+		{&st.RightTee, "rightTee", j.RightTee},
+	} {
+		r, size := utf8.DecodeRuneInString(f.text)
+		if size == 0 || size != len(f.text) {
+			return fmt.Errorf("%w: %s is %q, not one character", ErrInvalidStyle, f.name, f.text)
+		}
+		*f.dst = r
+	}
+	if err := st.Validate(); err != nil {
+		return err
+	}
+	*s = st
+	return nil
+}
+
+// themeJSON is the JSON form of a Theme. Each color is the list of its SGR
+// escape sequences by their parameters, so FgRed + Bold is ["31", "1"] and
+// Color256(28) is ["38;5;28"]; no color is an empty list.
+type themeJSON struct {
+	Version      int      `json:"version"`
+	Name         string   `json:"name"`
+	Added        []string `json:"added"`
+	Removed      []string `json:"removed"`
+	AddedSpace   []string `json:"addedSpace"`
+	RemovedSpace []string `json:"removedSpace"`
+	Muted        []string `json:"muted"`
+	Info         []string `json:"info"`
+	Success      []string `json:"success"`
+	Warning      []string `json:"warning"`
+	Error        []string `json:"error"`
+	Border       *Style   `json:"border,omitempty"`
+	Header       []string `json:"header"`
+	Body         []string `json:"body"`
+	StripeEven   []string `json:"stripeEven"`
+	StripeOdd    []string `json:"stripeOdd"`
+	Separators   bool     `json:"separators"`
+}
+
+// MarshalJSON writes the theme as an object with the schema "version", its
+// "name", one field per color named like the Theme fields in lower camel
+// case ("added", "removedSpace", "stripeOdd", ...), "separators", and the
+// "border" style as Style.MarshalJSON writes it, left out when zero. A color
+// is written as the parameters of its escape sequences, one string each, so
+// other languages can read it without parsing ANSI codes:
+//
+//	{"version": 1, "name": "dark", "added": ["32"], ..., "error": ["31", "1"], ...}
+//
+// A color that isn't made of SGR sequences (ESC [ ... m) is an error.
+func (th Theme) MarshalJSON() ([]byte, error) {
+	j := themeJSON{Version: AppearanceVersion, Name: th.Name, Separators: th.Separators}
+	if th.Border != (Style{}) {
+		j.Border = &th.Border
+	}
+	for _, f := range []struct {
+		dst  *[]string
+		name string
+		code string
+	}{
+		{&j.Added, "added", th.Added},
+		{&j.Removed, "removed", th.Removed},
+		{&j.AddedSpace, "addedSpace", th.AddedSpace},
+		{&j.RemovedSpace, "removedSpace", th.RemovedSpace},
+		{&j.Muted, "muted", th.Muted},
+		{&j.Info, "info", th.Info},
+		{&j.Success, "success", th.Success},
+		{&j.Warning, "warning", th.Warning},
+		{&j.Error, "error", th.Error},
+		{&j.Header, "header", th.Header},
+		{&j.Body, "body", th.Body},
+		{&j.StripeEven, "stripeEven", th.StripeEven},
+		{&j.StripeOdd, "stripeOdd", th.StripeOdd},
+	} {
+		params, ok := sgrParams(f.code)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s is %q, not SGR sequences", ErrInvalidTheme, f.name, f.code)
+		}
+		*f.dst = params
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON reads a theme written by MarshalJSON. A missing or null
+// color means none, a missing border the zero Style, a missing version 1,
+// and a newer one than AppearanceVersion is refused. Parameters may only
+// hold digits and ';'. Errors wrap ErrInvalidTheme, and ErrInvalidStyle as
+// well for a bad border, and leave th unchanged.
+func (th *Theme) UnmarshalJSON(data []byte) error {
+	var j themeJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidTheme, err)
+	}
+	if err := checkAppearanceVersion(j.Version); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidTheme, err)
+	}
+
+	t := Theme{Name: j.Name, Separators: j.Separators}
+	if j.Border != nil {
+		t.Border = *j.Border
+	}
+	for _, f := range []struct {
+		dst    *string
+		name   string
+		params []string
+	}{
+		{&t.Added, "added", j.Added},
+		{&t.Removed, "removed", j.Removed},
+		{&t.AddedSpace, "addedSpace", j.AddedSpace},
+		{&t.RemovedSpace, "removedSpace", j.RemovedSpace},
+		{&t.Muted, "muted", j.Muted},
+		{&t.Info, "info", j.Info},
+		{&t.Success, "success", j.Success},
+		{&t.Warning, "warning", j.Warning},
+		{&t.Error, "error", j.Error},
+		{&t.Header, "header", j.Header},
+		{&t.Body, "body", j.Body},
+		{&t.StripeEven, "stripeEven", j.StripeEven},
+		{&t.StripeOdd, "stripeOdd", j.StripeOdd},
+	} {
+		var b strings.Builder
+		for _, p := range f.params {
+			if !isSGRParams(p) {
+				return fmt.Errorf("%w: %s has %q, not SGR parameters", ErrInvalidTheme, f.name, p)
+			}
+			b.WriteString("\033[" + p + "m")
+		}
+		*f.dst = b.String()
+	}
+	*th = t
+	return nil
+}
+
+// checkAppearanceVersion reports an error for a schema version this release
+// can't read. 0 stands for a document without one.
+func checkAppearanceVersion(v int) error {
+	if v < 0 || v > AppearanceVersion {
+		return fmt.Errorf("schema version %d, this release reads up to %d", v, AppearanceVersion)
+	}
+	return nil
+}
+
+// sgrParams splits code into the parameters of its SGR sequences, reporting
+// false if it holds anything else. An empty code has none.
+func sgrParams(code string) ([]string, bool) {
+	params := []string{}
+	for code != "" {
+		rest, ok := strings.CutPrefix(code, "\033[")
+		if !ok {
+			return nil, false
+		}
+		p, rest, ok := strings.Cut(rest, "m")
+		if !ok || !isSGRParams(p) {
+			return nil, false
+		}
+		params = append(params, p)
+		code = rest
+	}
+	return params, true
+}
+
+// isSGRParams reports whether p is a valid parameter list for an SGR
+// sequence: digits separated by ';'.
+func isSGRParams(p string) bool {
+	for i := 0; i < len(p); i++ {
+		if (p[i] < '0' || p[i] > '9') && p[i] != ';' {
+			return false
+		}
+	}
+	return true
+}
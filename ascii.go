@@ -0,0 +1,87 @@
+// ascii.go
+
+package tables
+
+import "unicode/utf8"
+
+// regionalA is REGIONAL INDICATOR SYMBOL LETTER A; flag emoji are pairs of
+// these, one per letter of the ISO country code.
+const regionalA = 0x1F1E6
+
+// SetWideEmulation replaces every double-width glyph with a two-character ASCII
+// approximation at render time, so output stays aligned on terminals and fonts
+// that don't draw wide characters at double width:
+//
+//   - Fullwidth forms become their ASCII counterpart plus a space ("Ａ" → "A ")
+//   - CJK ideographs, kana and Hangul become "[]"
+//   - Emoji and other wide symbols become "::"
+//   - Flag emoji become their country code ("🇯🇵" → "JP")
+//   - Zero-width characters (combining marks, joiners) are dropped
+//
+// Stored data is not changed. ANSI sequences are preserved. Normal-width
+// non-ASCII characters such as "é" are left alone since they already occupy a
+// single column.
+func (t *Table) SetWideEmulation(on bool) *Table {
+	t.wideEmulation = on
+	return t
+}
+
+// ToASCII renders the table using StyleASCII borders with wide-glyph
+// emulation enabled, producing output that is aligned on any terminal, log
+// viewer or font. The table itself is left unchanged.
+func (t *Table) ToASCII() string {
+	cp := *t
+	cp.style = StyleASCII
+	cp.wideEmulation = true
+	return cp.String()
+}
+
+// emulateWide implements the substitutions documented on SetWideEmulation.
+func emulateWide(b []byte, widthFunc WidthFunc) []byte {
+	out := make([]byte, 0, len(b))
+	for len(b) > 0 {
+		// Copy escape sequences through untouched.
+		if b[0] == '\033' {
+			n, _ := scanEscape(b)
+			out = append(out, b[:n]...)
+			b = b[n:]
+			continue
+		}
+
+		r, size := utf8.DecodeRune(b)
+		b = b[size:]
+		if r < utf8.RuneSelf {
+			out = append(out, byte(r))
+			continue
+		}
+
+		switch {
+		case r >= regionalA && r < regionalA+26:
+			out = append(out, byte('A'+r-regionalA))
+		case widthFunc(r) == 0:
+			// drop
+		case widthFunc(r) < 2:
+			out = utf8.AppendRune(out, r)
+		case r >= 0xFF01 && r <= 0xFF5E:
+			out = append(out, byte(r-0xFF01+'!'), ' ')
+		case r == 0x3000:
+			out = append(out, ' ', ' ')
+		case isCJK(r):
+			out = append(out, '[', ']')
+		default:
+			out = append(out, ':', ':')
+		}
+	}
+	return out
+}
+
+// isCJK reports whether r is a Han, kana, Hangul or CJK punctuation rune.
+func isCJK(r rune) bool {
+	return (r >= 0x1100 && r <= 0x11FF) ||
+		(r >= 0x2E80 && r <= 0x31FF) ||
+		(r >= 0x3400 && r <= 0x4DBF) ||
+		(r >= 0x4E00 && r <= 0x9FFF) ||
+		(r >= 0xA960 && r <= 0xA97F) ||
+		(r >= 0xAC00 && r <= 0xD7AF) ||
+		(r >= 0x20000 && r <= 0x2EBEF)
+}
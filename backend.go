@@ -0,0 +1,304 @@
+// backend.go
+
+package tables
+
+import (
+	"bytes"
+	"errors"
+)
+
+// Layout describes the table a Backend is about to receive. Widths come from
+// the same measurement the text renderer uses, honoring SetMaxWidth,
+// LockWidths, the width function and the render-time content settings, so
+// every backend lays columns out alike.
+type Layout struct {
+	Widths []int   // Content width per column, in terminal cells
+	Aligns []Align // Alignment per column
+	Style  Style   // Border style
+	Rows   int     // Number of data rows, separators not counted
+}
+
+// Row is one row of the table body passed to Backend.WriteRow: either a data
+// row or a separator added with AddSeparator.
+type Row struct {
+	Index     int      // Data row index as used by SetRowColor; -1 for separators
+	Cells     [][]byte // Stored cell contents, ANSI sequences included; nil for separators
+	Separator bool
+	Spans     []int // Columns each cell covers, 0 where covered; nil except for AddSpannedRow rows
+
+	ascii bool // Every cell is printable ASCII (text renderer fast path)
+}
+
+// Backend receives a table's content in order, letting output formats reuse
+// the package's traversal and column measurement instead of walking the
+// table themselves. Render calls Begin once, WriteHeader once, WriteRow for
+// every body row (separators included) and End once. A non-nil error from
+// any method stops rendering and is returned by Render.
+//
+// Cells passed to a Backend are only valid during the call: their storage
+// is reused for the next row and must not be retained.
+type Backend interface {
+	Begin(layout Layout) error
+	WriteHeader(cells [][]byte) error
+	WriteRow(row Row) error
+	End(footer [][]byte) error // footer is nil when none is set
+}
+
+// Render drives b with the table's content. The built-in text output (String,
+// Print, WriteTo) is itself a Backend; other formats such as spreadsheets or
+// images can implement the interface to get the same layout.
+//
+// Example:
+//
+//	type tsv struct{ w io.Writer }
+//
+//	func (b tsv) Begin(tables.Layout) error        { return nil }
+//	func (b tsv) WriteHeader(cells [][]byte) error { return b.line(cells) }
+//	func (b tsv) WriteRow(r tables.Row) error {
+//	    if r.Separator {
+//	        return nil
+//	    }
+//	    return b.line(r.Cells)
+//	}
+//	func (b tsv) End([][]byte) error { return nil }
+//	func (b tsv) line(cells [][]byte) error {
+//	    _, err := fmt.Fprintf(b.w, "%s\n", bytes.Join(cells, []byte("\t")))
+//	    return err
+//	}
+//
+//	err := t.Render(tsv{os.Stdout})
+func (t *Table) Render(b Backend) error {
+	if len(t.headers) == 0 {
+		return nil
+	}
+	// Each view step returns t itself when its setting is off, so any other
+	// result is a copy with the settings applied and cleared.
+	if s := t.shown(); s != t {
+		return s.Render(b)
+	}
+
+	// The text renderer is trusted with the stored slices; anyone else gets
+	// a copy so the table can't be modified through the interface.
+	_, internal := b.(*textBackend)
+	var scratch rowScratch
+	view := func(cells [][]byte) [][]byte {
+		if internal || cells == nil {
+			return cells
+		}
+		return scratch.copy(cells)
+	}
+
+	layout := Layout{
+		Widths: t.columnWidths(),
+		Aligns: t.aligns,
+		Style:  t.style,
+		Rows:   t.dataRowsFrom(0),
+	}
+	if !internal {
+		layout.Widths = append([]int(nil), layout.Widths...)
+		layout.Aligns = append([]Align(nil), layout.Aligns...)
+	}
+
+	if err := b.Begin(layout); err != nil {
+		return err
+	}
+	if err := b.WriteHeader(view(t.headers)); err != nil {
+		return err
+	}
+
+	dataIdx := 0
+	for i, cells := range t.rows {
+		row := Row{Index: -1, Separator: true}
+		if t.rowKinds[i] == rowData {
+			row = Row{Index: dataIdx, Cells: view(cells), Spans: t.spansAt(i), ascii: t.rowASCII[i]}
+			if !internal && row.Spans != nil {
+				row.Spans = append([]int(nil), row.Spans...)
+			}
+			dataIdx++
+		}
+		if err := b.WriteRow(row); err != nil {
+			return err
+		}
+	}
+
+	return b.End(view(t.footer))
+}
+
+// rowScratch is reusable storage for handing out row copies.
+type rowScratch struct {
+	cells [][]byte
+	buf   []byte
+}
+
+// copy returns a copy of cells backed by the scratch storage, valid until
+// the next call.
+func (s *rowScratch) copy(cells [][]byte) [][]byte {
+	s.buf = s.buf[:0]
+	for _, cell := range cells {
+		s.buf = append(s.buf, cell...)
+	}
+	s.cells = append(s.cells[:0], cells...)
+	off := 0
+	for j, cell := range cells {
+		s.cells[j] = s.buf[off : off+len(cell) : off+len(cell)]
+		off += len(cell)
+	}
+	return s.cells
+}
+
+// errOutputLimit stops the text backend once SetMaxOutputBytes is reached.
+var errOutputLimit = errors.New("tables: output limit reached")
+
+// textBackend is the box-drawing terminal renderer behind String, Print and
+// WriteTo.
+type textBackend struct {
+	t       *Table
+	buf     *bytes.Buffer
+	widths  []int
+	rows    int           // data rows in the table
+	written int           // data rows rendered so far
+	start   int           // buf length before rendering began
+	reserve int           // bytes kept free to close a truncated table
+	pos     int           // position in the table's rows of the next row
+	header  [][]byte      // header cells, drawn again with SetHeaderRepeat
+	saved   rowScratch    // storage header is copied to, as cells don't outlive WriteHeader
+	lead    int           // lines above the last row's cells: a rule or a repeated header
+	above   []int         // column spans of the last row written
+	merges  [][]mergeKind // merged cells by position, see SetMergeRepeated
+	spans   [][]int       // column spans by position where row spans change them
+	merged  [][]byte      // scratch row with merged cells blanked
+}
+
+func (b *textBackend) Begin(layout Layout) error {
+	b.widths = layout.Widths
+	b.rows = layout.Rows
+	b.start = b.buf.Len()
+	b.merges, b.spans = b.t.merges()
+
+	// With an output cap, every row must leave room to close the table
+	if b.t.maxOutput > 0 {
+		b.reserve = b.t.borderLen(b.widths) + len(truncationNotice(b.rows, b.rows))
+	}
+	return nil
+}
+
+func (b *textBackend) WriteHeader(cells [][]byte) error {
+	b.header = b.saved.copy(cells)
+	b.t.renderBorder(b.buf, b.widths, b.t.renderTitle(b.buf, b.widths))
+	b.t.renderRow(b.buf, cells, b.widths, -1, rowIsASCII(cells), nil) // -1 = header
+	b.t.renderJoinedBorder(b.buf, b.widths, "middle", nil, b.spansAt(0), nil)
+	if b.overLimit() {
+		b.buf.Truncate(b.start)
+		b.t.renderTruncated(b.buf, nil, nil, b.rows)
+		return errOutputLimit
+	}
+	return nil
+}
+
+func (b *textBackend) WriteRow(row Row) error {
+	mark := b.buf.Len()
+	b.lead = 0
+	if row.Separator {
+		if b.headerDue() {
+			b.t.renderJoinedBorder(b.buf, b.widths, "middle", b.above, nil, nil)
+		} else {
+			b.t.renderJoinedBorder(b.buf, b.widths, "middle", b.above, b.spansAt(b.pos+1), b.openAt(b.pos+1))
+		}
+	} else {
+		repeat := b.headerDue()
+		if repeat {
+			b.repeatHeader()
+		} else if b.ruleAbove() {
+			b.t.renderJoinedBorder(b.buf, b.widths, "middle", b.above, b.spansAt(b.pos), b.openAt(b.pos))
+		}
+		b.lead = bytes.Count(b.buf.Bytes()[mark:], []byte("\n"))
+		cells, ascii := row.Cells, row.ascii
+		if b.pos < len(b.merges) && b.merges[b.pos] != nil {
+			merged := b.merges[b.pos]
+			if repeat {
+				merged = restartRuns(merged)
+			}
+			b.merged, ascii = b.t.mergedRow(b.merged, cells, merged, ascii)
+			cells = b.merged
+		}
+		b.t.renderRow(b.buf, cells, b.widths, row.Index, ascii, b.spansAt(b.pos))
+	}
+	if b.overLimit() {
+		b.buf.Truncate(mark)
+		b.t.renderTruncated(b.buf, b.widths, b.above, b.rows-b.written)
+		return errOutputLimit
+	}
+	if !row.Separator {
+		b.written++
+	}
+	b.above = b.spansAt(b.pos)
+	b.pos++
+	return nil
+}
+
+func (b *textBackend) End(footer [][]byte) error {
+	if footer == nil {
+		b.t.renderJoinedBorder(b.buf, b.widths, "bottom", b.above, nil, nil)
+		return nil
+	}
+
+	mark := b.buf.Len()
+	b.t.renderJoinedBorder(b.buf, b.widths, "middle", b.above, nil, nil)
+	b.t.renderRow(b.buf, footer, b.widths, -2, rowIsASCII(footer), nil) // -2 = footer sentinel
+	b.t.renderBorder(b.buf, b.widths, "bottom")
+	if b.t.maxOutput > 0 && b.buf.Len()-b.start > b.t.maxOutput {
+		b.buf.Truncate(mark)
+		b.t.renderTruncated(b.buf, b.widths, b.above, 0)
+	}
+	return nil
+}
+
+// headerDue reports whether the header is drawn again above the next data
+// row, SetHeaderRepeat rows after it was last drawn.
+func (b *textBackend) headerDue() bool {
+	n := b.t.reheader
+	return n > 0 && b.written > 0 && b.written%n == 0
+}
+
+// repeatHeader draws the header between rules above the data row at pos,
+// sharing the rule of a separator just before it.
+func (b *textBackend) repeatHeader() {
+	if b.pos == 0 || b.t.rowKinds[b.pos-1] != rowSeparator {
+		b.t.renderJoinedBorder(b.buf, b.widths, "middle", b.above, nil, nil)
+	}
+	b.t.renderRow(b.buf, b.header, b.widths, -1, rowIsASCII(b.header), nil) // -1 = header
+	b.t.renderJoinedBorder(b.buf, b.widths, "middle", nil, b.spansAt(b.pos), nil)
+}
+
+// ruleAbove reports whether the data row at pos gets a rule above it: with
+// SetRowSeparators on, when the row before it is a data row too.
+func (b *textBackend) ruleAbove() bool {
+	return b.t.rowRules && b.pos > 0 && b.t.rowKinds[b.pos-1] == rowData
+}
+
+// spansAt returns the column spans the row at pos renders with.
+func (b *textBackend) spansAt(pos int) []int {
+	if pos < len(b.spans) && b.spans[pos] != nil {
+		return b.spans[pos]
+	}
+	return b.t.spansAt(pos)
+}
+
+// openAt returns which columns a separator above the row at pos leaves open
+// for cells merged across it, nil if none.
+func (b *textBackend) openAt(pos int) []bool {
+	if pos >= len(b.merges) || b.merges[pos] == nil {
+		return nil
+	}
+	open := make([]bool, len(b.merges[pos]))
+	for col, kind := range b.merges[pos] {
+		open[col] = kind != mergeNone
+	}
+	return open
+}
+
+// overLimit reports whether the output so far, plus the room reserved for
+// closing the table, exceeds the SetMaxOutputBytes cap.
+func (b *textBackend) overLimit() bool {
+	return b.t.maxOutput > 0 && b.buf.Len()-b.start+b.reserve > b.t.maxOutput
+}
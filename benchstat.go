@@ -0,0 +1,193 @@
+// benchstat.go
+
+package tables
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// benchKey identifies one measured metric of one benchmark.
+type benchKey struct {
+	name, unit string
+}
+
+// benchSamples collects the measurements parsed from one benchmark run.
+type benchSamples struct {
+	values map[benchKey][]float64
+	order  []benchKey
+}
+
+// significanceThreshold is the |t| value above which a difference counts as
+// significant. It approximates a two-sided p < 0.05 for the sample counts
+// typically produced by `go test -count`.
+const significanceThreshold = 2.0
+
+// FromBenchstat parses two sets of `go test -bench` output (before and after a
+// change) and returns a comparison table with one row per benchmark and unit:
+// old mean, new mean and the relative delta.
+//
+// Repeated runs of the same benchmark (from -count) are averaged and their
+// spread is shown as ±percent. A delta is colored when Welch's t-test finds
+// the difference significant: green for improvements and red for regressions.
+// Insignificant deltas, or those with fewer than two samples on either side,
+// are shown as "~" like benchstat does. Units ending in "/s" (e.g. MB/s) are
+// treated as higher-is-better; everything else as lower-is-better.
+//
+// Example:
+//
+//	oldf, _ := os.Open("old.txt")
+//	newf, _ := os.Open("new.txt")
+//	t, err := tables.FromBenchstat(oldf, newf)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	t.Print()
+func FromBenchstat(old, new io.Reader) (*Table, error) {
+	before, err := parseBench(old)
+	if err != nil {
+		return nil, fmt.Errorf("tables: parsing old benchmarks: %w", err)
+	}
+	after, err := parseBench(new)
+	if err != nil {
+		return nil, fmt.Errorf("tables: parsing new benchmarks: %w", err)
+	}
+
+	t := NewFromStrings("Benchmark", "Unit", "Old", "New", "Delta").
+		SetHeaderColor(NewColor().WithStyle(Bold)).
+		SetAlign(2, AlignRight).
+		SetAlign(3, AlignRight).
+		SetAlign(4, AlignRight)
+
+	keys := before.order
+	for _, k := range after.order {
+		if _, ok := before.values[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	for _, k := range keys {
+		a, b := before.values[k], after.values[k]
+		t.AddRow(k.name, k.unit, formatBenchSamples(a), formatBenchSamples(b), benchDelta(a, b, strings.HasSuffix(k.unit, "/s")))
+	}
+	return t, nil
+}
+
+// parseBench reads benchmark result lines of the form
+//
+//	BenchmarkName-8   1000   1234 ns/op   56 B/op   2 allocs/op
+//
+// ignoring everything else (goos/goarch headers, PASS, ok lines, logs).
+func parseBench(r io.Reader) (*benchSamples, error) {
+	s := &benchSamples{values: make(map[benchKey][]float64)}
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		if _, err := strconv.Atoi(fields[1]); err != nil {
+			continue // not a result line
+		}
+
+		name := trimProcs(strings.TrimPrefix(fields[0], "Benchmark"))
+		for i := 2; i+1 < len(fields); i += 2 {
+			v, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				break
+			}
+			k := benchKey{name: name, unit: fields[i+1]}
+			if _, ok := s.values[k]; !ok {
+				s.order = append(s.order, k)
+			}
+			s.values[k] = append(s.values[k], v)
+		}
+	}
+	return s, sc.Err()
+}
+
+// trimProcs drops the -GOMAXPROCS suffix ("Parse-8" → "Parse") so results
+// from machines with different core counts still line up.
+func trimProcs(name string) string {
+	i := strings.LastIndexByte(name, '-')
+	if i < 0 {
+		return name
+	}
+	if _, err := strconv.Atoi(name[i+1:]); err != nil {
+		return name
+	}
+	return name[:i]
+}
+
+// meanStddev returns the sample mean and standard deviation of v.
+func meanStddev(v []float64) (mean, stddev float64) {
+	for _, x := range v {
+		mean += x
+	}
+	mean /= float64(len(v))
+	if len(v) < 2 {
+		return mean, 0
+	}
+	for _, x := range v {
+		stddev += (x - mean) * (x - mean)
+	}
+	return mean, math.Sqrt(stddev / float64(len(v)-1))
+}
+
+// formatBenchSamples formats the mean of v with its relative spread.
+func formatBenchSamples(v []float64) string {
+	if len(v) == 0 {
+		return "-"
+	}
+	mean, sd := meanStddev(v)
+	s := formatBenchValue(mean)
+	if len(v) > 1 && mean != 0 {
+		s += " ±" + strconv.FormatFloat(100*sd/mean, 'f', 0, 64) + "%"
+	}
+	return s
+}
+
+// formatBenchValue picks a precision that keeps values readable without
+// scientific notation.
+func formatBenchValue(v float64) string {
+	switch a := math.Abs(v); {
+	case a >= 100:
+		return strconv.FormatFloat(v, 'f', 0, 64)
+	case a >= 10:
+		return strconv.FormatFloat(v, 'f', 1, 64)
+	default:
+		return strconv.FormatFloat(v, 'f', 2, 64)
+	}
+}
+
+// benchDelta returns the colored relative change from a to b, or "~" when the
+// change is not statistically significant.
+func benchDelta(a, b []float64, higherIsBetter bool) string {
+	if len(a) == 0 || len(b) == 0 {
+		return ""
+	}
+	m1, s1 := meanStddev(a)
+	m2, s2 := meanStddev(b)
+	if m1 == 0 || len(a) < 2 || len(b) < 2 {
+		return Sprint("~", DefaultTheme.Muted)
+	}
+
+	se := math.Sqrt(s1*s1/float64(len(a)) + s2*s2/float64(len(b)))
+	if m1 == m2 || (se > 0 && math.Abs(m2-m1)/se < significanceThreshold) {
+		return Sprint("~", DefaultTheme.Muted)
+	}
+
+	pct := 100 * (m2 - m1) / m1
+	text := strconv.FormatFloat(pct, 'f', 2, 64) + "%"
+	if pct > 0 {
+		text = "+" + text
+	}
+	if (pct < 0) != higherIsBetter {
+		return Sprint(text, DefaultTheme.Added)
+	}
+	return Sprint(text, DefaultTheme.Removed)
+}
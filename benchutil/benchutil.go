@@ -0,0 +1,186 @@
+// Package benchutil measures table rendering on a fixed set of workloads, so
+// a custom WidthFunc, style or rendering path can be checked against the
+// library's defaults before it goes into a performance-sensitive program.
+//
+// Benchmark runs a RenderFunc over the Workloads as sub-benchmarks of a go
+// test benchmark. Compare runs two of them from any program, checking that
+// they render the same, and Table shows the results with the change in
+// speed.
+package benchutil
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	tables "github.com/architmishra-15/go-tables"
+)
+
+// RenderFunc renders a workload table. It may configure t first, with
+// SetWidthFunc for example; t is built afresh for each measurement.
+type RenderFunc func(t *tables.Table) string
+
+// Default renders with the library's defaults, the baseline for Compare.
+var Default RenderFunc = (*tables.Table).String
+
+// Workload is a table to render, built the same way every time.
+type Workload struct {
+	Name  string               // "ascii-small", "cjk-large" or "ansi-heavy"
+	Build func() *tables.Table // Returns a new table holding the workload
+}
+
+// Workloads returns the standard workloads:
+//
+//   - ascii-small: 20 rows of short ASCII values, the common CLI case
+//   - cjk-large: 5000 rows of Chinese and Japanese text, measured glyph by glyph
+//   - ansi-heavy: 1000 rows of colored cells, with escape sequences to skip
+func Workloads() []Workload {
+	return []Workload{
+		{"ascii-small", asciiSmall},
+		{"cjk-large", cjkLarge},
+		{"ansi-heavy", ansiHeavy},
+	}
+}
+
+func asciiSmall() *tables.Table {
+	t := tables.NewFromStrings("ID", "Name", "Status", "Latency")
+	for i := range 20 {
+		t.AddRow(i, "service-"+strconv.Itoa(i), []string{"ok", "degraded", "down"}[i%3], fmt.Sprintf("%d.%02dms", i*7%90, i*13%100))
+	}
+	return t
+}
+
+func cjkLarge() *tables.Table {
+	words := []string{"東京", "大阪府", "北京市", "上海", "こんにちは", "データベース", "表格渲染", "名古屋"}
+	t := tables.NewFromStrings("#", "都市", "説明", "備考", "値")
+	for i := range 5000 {
+		t.AddRow(i, words[i%len(words)], words[(i+3)%len(words)]+"と"+words[(i+5)%len(words)], words[(i*7)%len(words)], i*31%1000)
+	}
+	return t
+}
+
+func ansiHeavy() *tables.Table {
+	colors := []string{tables.FgRed, tables.FgGreen, tables.FgYellow, tables.FgBlue, tables.FgCyan}
+	t := tables.NewFromStrings("Job", "State", "Owner", "Progress")
+	for i := range 1000 {
+		c := colors[i%len(colors)]
+		t.AddRow(
+			tables.Sprint("job-"+strconv.Itoa(i), tables.Bold),
+			tables.Sprint([]string{"running", "failed", "queued"}[i%3], c),
+			tables.Sprint("team-"+strconv.Itoa(i%12), tables.Dim),
+			tables.Sprint(strconv.Itoa(i%101)+"%", colors[(i+2)%len(colors)]),
+		)
+	}
+	return t.SetHeaderColor(tables.NewColor().WithStyle(tables.Bold)).
+		SetColumnColor(2, tables.NewColor().WithFg(tables.FgMagenta))
+}
+
+// Benchmark runs fn on every workload as a sub-benchmark of b, for use in a
+// Benchmark function of the caller's own tests.
+//
+// Example:
+//
+//	func BenchmarkRender(b *testing.B) {
+//	    benchutil.Benchmark(b, func(t *tables.Table) string {
+//	        return t.SetWidthFunc(myWidth).String()
+//	    })
+//	}
+func Benchmark(b *testing.B, fn RenderFunc) {
+	for _, w := range Workloads() {
+		b.Run(w.Name, func(b *testing.B) {
+			t := w.Build()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for b.Loop() {
+				fn(t)
+			}
+		})
+	}
+}
+
+// Result is the comparison of two RenderFuncs on one workload.
+type Result struct {
+	Workload string
+	Old, New testing.BenchmarkResult
+	Same     bool // Both rendered the workload identically
+}
+
+// Delta returns the change in time per render from Old to New as a
+// fraction: -0.25 is 25% faster.
+func (r Result) Delta() float64 {
+	old := r.Old.NsPerOp()
+	if old == 0 {
+		return 0
+	}
+	return float64(r.New.NsPerOp()-old) / float64(old)
+}
+
+// Compare benchmarks old and new on every workload and reports how they
+// differ, in time, allocations and output. Each benchmark runs for the
+// -test.benchtime duration, one second unless set, so a comparison takes a
+// few seconds.
+//
+// Example:
+//
+//	results := benchutil.Compare(benchutil.Default, func(t *tables.Table) string {
+//	    return t.SetWidthFunc(myWidth).String()
+//	})
+//	benchutil.Table(results).Print()
+func Compare(old, new RenderFunc) []Result {
+	results := make([]Result, 0, 3)
+	for _, w := range Workloads() {
+		r := Result{Workload: w.Name}
+		r.Same = old(w.Build()) == new(w.Build())
+		r.Old = run(w, old)
+		r.New = run(w, new)
+		results = append(results, r)
+	}
+	return results
+}
+
+// run benchmarks fn on w.
+func run(w Workload, fn RenderFunc) testing.BenchmarkResult {
+	return testing.Benchmark(func(b *testing.B) {
+		t := w.Build()
+		b.ReportAllocs()
+		b.ResetTimer()
+		for b.Loop() {
+			fn(t)
+		}
+	})
+}
+
+// Table builds a table of results, one row per workload, with the time per
+// render, its change and the allocations of each side. Output differences
+// are flagged in the last column.
+func Table(results []Result) *tables.Table {
+	t := tables.NewFromStrings("Workload", "Old", "New", "Delta", "Old allocs", "New allocs", "Output").
+		SetAlign(1, tables.AlignRight).
+		SetAlign(2, tables.AlignRight).
+		SetAlign(3, tables.AlignRight).
+		SetAlign(4, tables.AlignRight).
+		SetAlign(5, tables.AlignRight)
+
+	for _, r := range results {
+		output := "same"
+		if !r.Same {
+			output = tables.Warning("differs")
+		}
+		t.AddRow(r.Workload,
+			formatNs(r.Old.NsPerOp()), formatNs(r.New.NsPerOp()),
+			fmt.Sprintf("%+.1f%%", 100*r.Delta()),
+			r.Old.AllocsPerOp(), r.New.AllocsPerOp(), output)
+	}
+	return t
+}
+
+// formatNs formats a duration in nanoseconds with a unit suited to its size.
+func formatNs(ns int64) string {
+	switch {
+	case ns >= 1e6:
+		return fmt.Sprintf("%.2fms", float64(ns)/1e6)
+	case ns >= 1e3:
+		return fmt.Sprintf("%.2fµs", float64(ns)/1e3)
+	}
+	return strconv.FormatInt(ns, 10) + "ns"
+}
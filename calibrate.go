@@ -0,0 +1,112 @@
+// calibrate.go
+
+package tables
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// wideClass groups the runes RuneWidth reports as wide into classes whose
+// rendered width commonly differs between terminals.
+type wideClass int
+
+const (
+	wideCJK    wideClass = iota // ideographs, kana, Hangul, fullwidth forms
+	wideEmoji                   // U+1F300–U+1FAFF pictographs
+	wideSymbol                  // enclosed alphanumerics, shapes, dingbats
+	numWideClasses
+)
+
+// widthProbes holds one representative rune per wide class.
+var widthProbes = [numWideClasses]rune{
+	wideCJK:    '中',
+	wideEmoji:  '😀',
+	wideSymbol: '★',
+}
+
+// classifyWide returns the wide class of a rune RuneWidth reports as wide.
+func classifyWide(r rune) wideClass {
+	switch {
+	case r >= 0x1F300 && r <= 0x1FAFF:
+		return wideEmoji
+	case r >= 0x2460 && r <= 0x27BF:
+		return wideSymbol
+	default:
+		return wideCJK
+	}
+}
+
+// CalibrateWidth measures how the terminal behind w actually renders wide
+// characters and installs a matching WidthFunc as DefaultWidthFunc, so tables
+// created afterwards line up on that terminal. The function is also returned
+// for use with SetWidthFunc on existing tables.
+//
+// For each class of wide character (CJK, emoji, symbols such as ★) a probe
+// glyph is printed followed by a cursor-position query, and the terminal's
+// reply is read from standard input. The probe line is erased afterwards.
+//
+// w and standard input must both be an interactive terminal; otherwise, or if
+// the terminal does not answer within half a second, an error is returned and
+// DefaultWidthFunc is left unchanged.
+func CalibrateWidth(w io.Writer) (WidthFunc, error) {
+	if !isTerminal(w) {
+		return nil, errNoTerminal
+	}
+	restore, err := makeRaw(os.Stdin.Fd(), 5)
+	if err != nil {
+		return nil, err
+	}
+	defer restore()
+	defer fmt.Fprint(w, "\r\033[K")
+
+	var widths [numWideClasses]int
+	for class, probe := range widthProbes {
+		if _, err := fmt.Fprintf(w, "\r\033[K%c\033[6n", probe); err != nil {
+			return nil, err
+		}
+		col, err := readCursorColumn(os.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		widths[class] = col - 1
+	}
+
+	fn := func(r rune) int {
+		w := RuneWidth(r)
+		if w != 2 {
+			return w
+		}
+		return widths[classifyWide(r)]
+	}
+	DefaultWidthFunc = fn
+	return fn, nil
+}
+
+// readCursorColumn reads a cursor position report ("ESC [ row ; col R") from
+// r and returns the 1-based column.
+func readCursorColumn(r io.Reader) (int, error) {
+	var reply []byte
+	b := make([]byte, 1)
+	for len(reply) < 32 {
+		n, err := r.Read(b)
+		if n == 0 || err != nil {
+			return 0, errors.New("tables: terminal did not answer cursor position query")
+		}
+		reply = append(reply, b[0])
+		if b[0] == 'R' {
+			break
+		}
+	}
+
+	start := bytes.LastIndex(reply, []byte("\033["))
+	semi := bytes.LastIndexByte(reply, ';')
+	if start < 0 || semi < start || reply[len(reply)-1] != 'R' {
+		return 0, fmt.Errorf("tables: malformed cursor position reply %q", reply)
+	}
+	return strconv.Atoi(string(reply[semi+1 : len(reply)-1]))
+}
@@ -0,0 +1,63 @@
+// colormode.go
+
+package tables
+
+import (
+	"io"
+)
+
+// ColorMode decides whether output written to an io.Writer keeps its ANSI
+// sequences.
+type ColorMode int
+
+const (
+	// ColorAuto keeps ANSI sequences when writing to a terminal and strips
+	// them when writing to anything else: a file, a pipe, a buffer.
+	ColorAuto ColorMode = iota
+	// ColorAlways keeps ANSI sequences wherever the output goes.
+	ColorAlways
+	// ColorNever strips ANSI sequences wherever the output goes.
+	ColorNever
+)
+
+// FprintColorMode is the ColorMode of Fprint and Fprintln. With the default,
+// ColorAuto, they only add codes when w is a terminal.
+var FprintColorMode = ColorAuto
+
+// colorsFor reports whether output written to w in mode m keeps its ANSI
+// sequences.
+func (m ColorMode) colorsFor(w io.Writer) bool {
+	switch m {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	return isTerminal(w)
+}
+
+// SetColorMode sets whether WriteTo, Print and PrintVertical keep the ANSI
+// sequences of the rendered table: the colors set on it and any codes in
+// the cells themselves. With the default, ColorAuto, they are kept for a
+// terminal and stripped when the output is piped or redirected, so logs and
+// files get plain text; ColorAlways keeps them, for a pager run with -R or a
+// writer that is a terminal without a file descriptor, and ColorNever always
+// strips them. The layout is the same either way. String and the exporters
+// are not affected.
+//
+// Example:
+//
+//	t.SetColorMode(tables.ColorAlways).WriteTo(pager)
+func (t *Table) SetColorMode(m ColorMode) *Table {
+	t.colorMode = m
+	return t
+}
+
+// forWriter returns out, the rendered table, as it is to be written to w:
+// with its ANSI sequences stripped when the ColorMode says so.
+func (t *Table) forWriter(w io.Writer, out []byte) []byte {
+	if t.colorMode.colorsFor(w) {
+		return out
+	}
+	return StripANSIBytes(out)
+}
@@ -3,6 +3,7 @@
 package tables
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"strings"
@@ -137,13 +138,20 @@ func Printf(format string, a ...interface{}) {
 }
 
 // Fprint writes the formatted string with the specified codes to the provided writer.
+// The codes are left out when w isn't a terminal, unless FprintColorMode says otherwise.
 func Fprint(w io.Writer, text string, codes ...string) (n int, err error) {
+	if !FprintColorMode.colorsFor(w) {
+		return fmt.Fprint(w, text)
+	}
 	return fmt.Fprint(w, Colorize(text, codes...))
 }
 
 // Fprintln writes the formatted string with the specified codes to the provided writer,
-// followed by a newline.
+// followed by a newline. The codes are left out as by Fprint.
 func Fprintln(w io.Writer, text string, codes ...string) (n int, err error) {
+	if !FprintColorMode.colorsFor(w) {
+		return fmt.Fprintln(w, text)
+	}
 	return fmt.Fprintln(w, Colorize(text, codes...))
 }
 
@@ -173,75 +181,85 @@ func BgTrueColor(r, g, b int) string {
 
 // --- Convenience Functions ---
 
-func Info(text string) string    { return Sprint(text, FgBlue) }
-func Success(text string) string { return Sprint(text, FgGreen, Bold) }
-func Warning(text string) string { return Sprint(text, FgYellow) }
-func Error(text string) string   { return Sprint(text, FgRed, Bold) }
+func Info(text string) string    { return Sprint(text, DefaultTheme.Info) }
+func Success(text string) string { return Sprint(text, DefaultTheme.Success) }
+func Warning(text string) string { return Sprint(text, DefaultTheme.Warning) }
+func Error(text string) string   { return Sprint(text, DefaultTheme.Error) }
 
 // ========== ANSI HANDLING FOR TABLE LIBRARY ==========
 // These functions are needed for proper width calculation in tables
 
-// ansiState represents the state of ANSI escape sequence parsing
-type ansiState int
+// escapeKind classifies an escape sequence found in text.
+type escapeKind int
 
 const (
-	ansiNormal ansiState = iota
-	ansiEscape
-	ansiCSI
+	escSGR    escapeKind = iota // ESC [ ... m — colors and text attributes
+	escCSI                      // any other control sequence (cursor movement, erase, ...)
+	escString                   // OSC, DCS, APC, PM, SOS — terminated by BEL or ESC \
+	escOther                    // short sequences such as ESC 7 (save cursor) or ESC ( B
 )
 
-// HasANSI returns true if the string contains ANSI escape sequences
-func HasANSI(s string) bool {
-	for i := 0; i < len(s); i++ {
-		if s[i] == '\033' && i+1 < len(s) && s[i+1] == '[' {
-			return true
+// scanEscape returns the length and kind of the escape sequence starting at
+// s[0], which must be ESC. Unterminated sequences extend to the end of s so
+// that a truncated sequence never leaks half-parsed bytes into the output.
+func scanEscape[T string | []byte](s T) (int, escapeKind) {
+	if len(s) < 2 {
+		return len(s), escOther
+	}
+
+	switch s[1] {
+	case '[':
+		// CSI: parameter and intermediate bytes, then a final byte 0x40–0x7E.
+		for i := 2; i < len(s); i++ {
+			if c := s[i]; c >= 0x40 && c <= 0x7E {
+				if c == 'm' {
+					return i + 1, escSGR
+				}
+				return i + 1, escCSI
+			}
+		}
+		return len(s), escCSI
+
+	case ']', 'P', '_', '^', 'X':
+		// Control strings end with BEL or ST (ESC \).
+		for i := 2; i < len(s); i++ {
+			if s[i] == 0x07 {
+				return i + 1, escString
+			}
+			if s[i] == 0x1b && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2, escString
+			}
+		}
+		return len(s), escString
+
+	default:
+		// Intermediate bytes 0x20–0x2F followed by one final byte.
+		i := 1
+		for i < len(s) && s[i] >= 0x20 && s[i] <= 0x2F {
+			i++
 		}
+		return min(i+1, len(s)), escOther
 	}
-	return false
+}
+
+// HasANSI returns true if the string contains ANSI escape sequences
+func HasANSI(s string) bool {
+	return strings.IndexByte(s, '\033') >= 0
 }
 
 // HasANSIBytes returns true if the byte slice contains ANSI escape sequences
 func HasANSIBytes(b []byte) bool {
-	for i := 0; i < len(b); i++ {
-		if b[i] == '\033' && i+1 < len(b) && b[i+1] == '[' {
-			return true
-		}
-	}
-	return false
+	return bytes.IndexByte(b, '\033') >= 0
 }
 
-// StripANSI removes all ANSI escape sequences from a string
-// This is optimized for performance with minimal allocations
+// StripANSI removes all ANSI escape sequences from a string: colors and other
+// CSI sequences, OSC strings such as window titles and hyperlinks, and short
+// ESC sequences. This is optimized for performance with minimal allocations
 func StripANSI(s string) string {
 	if !HasANSI(s) {
 		return s // Fast path: no ANSI sequences
 	}
-
-	result := make([]byte, 0, len(s))
-	state := ansiNormal
-
-	for i := 0; i < len(s); i++ {
-		b := s[i]
-
-		switch state {
-		case ansiNormal:
-			if b == '\033' && i+1 < len(s) && s[i+1] == '[' {
-				state = ansiCSI
-				i++ // Skip the '['
-			} else {
-				result = append(result, b)
-			}
-
-		case ansiCSI:
-			// CSI sequence ends with a letter (A-Z, a-z) or specific characters
-			if (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || b == '~' {
-				state = ansiNormal
-			}
-			// Skip all characters in CSI sequence
-		}
-	}
-
-	return string(result)
+	return string(stripEscapes(s))
 }
 
 // StripANSIBytes removes all ANSI escape sequences from a byte slice
@@ -250,31 +268,21 @@ func StripANSIBytes(b []byte) []byte {
 	if !HasANSIBytes(b) {
 		return b // Fast path: no ANSI sequences
 	}
+	return stripEscapes(b)
+}
 
-	result := make([]byte, 0, len(b))
-	state := ansiNormal
-
-	for i := 0; i < len(b); i++ {
-		ch := b[i]
-
-		switch state {
-		case ansiNormal:
-			if ch == '\033' && i+1 < len(b) && b[i+1] == '[' {
-				state = ansiCSI
-				i++ // Skip the '['
-			} else {
-				result = append(result, ch)
-			}
-
-		case ansiCSI:
-			// CSI sequence ends with a letter (A-Z, a-z) or specific characters
-			if (ch >= 'A' && ch <= 'Z') || (ch >= 'a' && ch <= 'z') || ch == '~' {
-				state = ansiNormal
-			}
-			// Skip all characters in CSI sequence
+// stripEscapes is the shared implementation of StripANSI and StripANSIBytes.
+func stripEscapes[T string | []byte](s T) []byte {
+	result := make([]byte, 0, len(s))
+	for i := 0; i < len(s); {
+		if s[i] == '\033' {
+			n, _ := scanEscape(s[i:])
+			i += n
+			continue
 		}
+		result = append(result, s[i])
+		i++
 	}
-
 	return result
 }
 
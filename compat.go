@@ -0,0 +1,173 @@
+// compat.go
+
+package tables
+
+import (
+	"fmt"
+	"slices"
+	"unicode/utf8"
+)
+
+// compatReplacement is written in place of characters outside the safe set.
+const compatReplacement = '?'
+
+// CompatIssue describes a cell whose content falls outside the safe character
+// set used by RenderCompat. Row is the 0-indexed data row (separators not
+// counted), or -1 for the header and -2 for the footer.
+type CompatIssue struct {
+	Row, Col int
+	Runes    []rune // Offending characters, in order of first appearance
+}
+
+// String formats the issue for logs, e.g. `row 2, col 1: U+4E16 '世'`.
+func (c CompatIssue) String() string {
+	var where string
+	switch c.Row {
+	case -1:
+		where = fmt.Sprintf("header, col %d", c.Col)
+	case -2:
+		where = fmt.Sprintf("footer, col %d", c.Col)
+	default:
+		where = fmt.Sprintf("row %d, col %d", c.Row, c.Col)
+	}
+	for i, r := range c.Runes {
+		if i == 0 {
+			where += ": "
+		} else {
+			where += ", "
+		}
+		where += fmt.Sprintf("%U %q", r, r)
+	}
+	return where
+}
+
+// SetCompatAllowlist sets the characters, besides printable ASCII, that
+// RenderCompat may emit. For example "éü°" keeps a few Latin-1 characters a
+// log viewer is known to handle. An empty string restricts output to ASCII.
+func (t *Table) SetCompatAllowlist(chars string) *Table {
+	t.compatAllow = nil
+	for _, r := range chars {
+		if t.compatAllow == nil {
+			t.compatAllow = make(map[rune]bool)
+		}
+		t.compatAllow[r] = true
+	}
+	return t
+}
+
+// RenderCompat renders the table for environments that only display a known
+// set of characters, such as serial consoles and legacy CI log viewers. The
+// output contains only printable ASCII, newlines and the characters given to
+// SetCompatAllowlist:
+//
+//   - Borders fall back to StyleASCII unless every border rune is allowed
+//   - Escape sequences, including colors and hyperlinks, are removed
+//   - Any other character is replaced with '?'
+//
+// Every cell that needed a replacement is reported in issues, so callers can
+// log or reject data that would otherwise be silently mangled. The table
+// itself is left unchanged.
+func (t *Table) RenderCompat() (out string, issues []CompatIssue) {
+	t = t.warm()
+	for i, h := range t.headers {
+		issues = t.compatCheck(issues, -1, i, h)
+	}
+	dataIdx := 0
+	for i, row := range t.rows {
+		if t.rowKinds[i] == rowSeparator {
+			continue
+		}
+		for j, cell := range row {
+			issues = t.compatCheck(issues, dataIdx, j, cell)
+		}
+		dataIdx++
+	}
+	for i, cell := range t.footer {
+		issues = t.compatCheck(issues, -2, i, cell)
+	}
+
+	cp := *t
+	cp.compatMode = true
+	if !cp.compatStyle() {
+		cp.style = StyleASCII
+	}
+	return StripANSI(cp.String()), issues
+}
+
+// compatAllowed reports whether r may appear in RenderCompat output.
+func (t *Table) compatAllowed(r rune) bool {
+	return (r >= 0x20 && r <= 0x7E) || t.compatAllow[r]
+}
+
+// compatStyle reports whether every border rune of the style is allowed.
+func (t *Table) compatStyle() bool {
+	s := t.style
+	for _, r := range []rune{
+		s.TopLeft, s.TopRight, s.BottomLeft, s.BottomRight, s.Horizontal,
+		s.Vertical, s.Cross, s.TopTee, s.BottomTee, s.LeftTee, s.RightTee,
+	} {
+		if !t.compatAllowed(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// compatCheck appends an issue for cell to issues if displaying it would
+// require replacements. Printable ASCII cells are skipped without decoding.
+func (t *Table) compatCheck(issues []CompatIssue, row, col int, cell []byte) []CompatIssue {
+	if isPrintableASCII(cell) {
+		return issues
+	}
+
+	var bad []rune
+	forEachRune(t.display(cell, -1), func(r rune) {
+		if !t.compatAllowed(r) && !slices.Contains(bad, r) {
+			bad = append(bad, r)
+		}
+	})
+	if bad == nil {
+		return issues
+	}
+	return append(issues, CompatIssue{Row: row, Col: col, Runes: bad})
+}
+
+// compatCell replaces every disallowed character in cell with '?', copying
+// escape sequences through for the final strip.
+func (t *Table) compatCell(cell []byte) []byte {
+	out := make([]byte, 0, len(cell))
+	for len(cell) > 0 {
+		if cell[0] == '\033' {
+			n, _ := scanEscape(cell)
+			out = append(out, cell[:n]...)
+			cell = cell[n:]
+			continue
+		}
+
+		r, size := utf8.DecodeRune(cell)
+		if r == '\t' && t.pasteMode {
+			out = append(out, ' ')
+		} else if (r == utf8.RuneError && size == 1) || !t.compatAllowed(r) {
+			out = append(out, compatReplacement)
+		} else {
+			out = append(out, cell[:size]...)
+		}
+		cell = cell[size:]
+	}
+	return out
+}
+
+// forEachRune calls fn for every rune of b outside escape sequences. Invalid
+// UTF-8 bytes are reported as utf8.RuneError.
+func forEachRune(b []byte, fn func(rune)) {
+	for len(b) > 0 {
+		if b[0] == '\033' {
+			n, _ := scanEscape(b)
+			b = b[n:]
+			continue
+		}
+		r, size := utf8.DecodeRune(b)
+		fn(r)
+		b = b[size:]
+	}
+}
@@ -0,0 +1,153 @@
+// compress.go
+
+package tables
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"io"
+)
+
+// coldBlockRows is the number of data rows SetCompression compresses
+// together: enough for repeated values across rows to compress well, few
+// enough that a block is quick to restore.
+const coldBlockRows = 64
+
+// coldBlock is a run of rows whose cells are stored compressed.
+type coldBlock struct {
+	first, end int    // Positions in rows covered
+	data       []byte // Deflated cells of the data rows among them
+}
+
+// SetCompression keeps only the newest hot data rows of the table as they
+// are and stores older ones compressed with DEFLATE, in blocks of 64 rows, to
+// cut the resident memory of long-lived tables with a lot of text, such as
+// logs kept for a dashboard. Rows are compressed as they age, while rows are
+// added.
+//
+// The compression is transparent. Rendering, Live displays and pages
+// decompress the rows for as long as they need them, and the exporters and
+// methods reading cells, such as Rows or Column, do the same. A method that
+// changes or reorders existing rows, such as SetCell, UpdateRow or SortBy,
+// decompresses the whole table first, and it is compressed again as rows are
+// added. Compression pays off for tables that are mostly appended to and
+// rendered now and then; a table re-rendered many times a second spends that
+// time decompressing. hot <= 0 turns compression off and decompresses every
+// row.
+//
+// Example:
+//
+//	log := tables.NewFromStrings("Time", "Level", "Message").
+//	    SetCompression(500) // the newest 500 rows stay uncompressed
+func (t *Table) SetCompression(hot int) *Table {
+	if hot <= 0 {
+		t.thaw()
+		t.hotRows = 0
+		return t
+	}
+	t.hotRows = hot
+	t.compressCold()
+	return t
+}
+
+// compressCold compresses the oldest rows not yet compressed, a block at a
+// time, for as long as more than hotRows data rows would remain.
+func (t *Table) compressCold() {
+	start := 0
+	if n := len(t.cold); n > 0 {
+		start = t.cold[n-1].end
+	}
+	for len(t.rows)-start >= t.hotRows+coldBlockRows {
+		// Position after the block's last data row, and the data rows
+		// after it.
+		end, n := start, 0
+		for ; end < len(t.rows) && n < coldBlockRows; end++ {
+			if t.rowKinds[end] == rowData {
+				n++
+			}
+		}
+		after := 0
+		for pos := end; pos < len(t.rows); pos++ {
+			if t.rowKinds[pos] == rowData {
+				after++
+			}
+		}
+		if n < coldBlockRows || after < t.hotRows {
+			return
+		}
+
+		var raw bytes.Buffer
+		for pos := start; pos < end; pos++ {
+			if t.rowKinds[pos] != rowData {
+				continue
+			}
+			raw.Write(binary.AppendUvarint(nil, uint64(len(t.rows[pos]))))
+			for _, cell := range t.rows[pos] {
+				raw.Write(binary.AppendUvarint(nil, uint64(len(cell))))
+				raw.Write(cell)
+			}
+		}
+		var packed bytes.Buffer
+		zw, _ := flate.NewWriter(&packed, flate.BestSpeed) // only fails for a bad level
+		zw.Write(raw.Bytes())
+		zw.Close()
+
+		t.cold = append(t.cold, coldBlock{first: start, end: end, data: bytes.Clone(packed.Bytes())})
+		for pos := start; pos < end; pos++ {
+			t.rows[pos] = nil
+		}
+		start = end
+	}
+}
+
+// decompress restores the cells of block b into rows, which is parallel to
+// the table's rows.
+func (t *Table) decompress(b coldBlock, rows [][][]byte) {
+	raw, err := io.ReadAll(flate.NewReader(bytes.NewReader(b.data)))
+	if err != nil {
+		panic("tables: corrupt compressed rows: " + err.Error()) // written by compressCold, never by anyone else
+	}
+	next := func() int {
+		n, k := binary.Uvarint(raw)
+		raw = raw[k:]
+		return int(n)
+	}
+	for pos := b.first; pos < b.end; pos++ {
+		if t.rowKinds[pos] != rowData {
+			continue
+		}
+		row := make([][]byte, next())
+		for i := range row {
+			n := next()
+			row[i] = raw[:n:n]
+			raw = raw[n:]
+		}
+		rows[pos] = row
+	}
+}
+
+// warm returns t itself, or with compressed rows a copy with every row
+// decompressed, for reading the cells without changing t.
+func (t *Table) warm() *Table {
+	if t.cold == nil {
+		return t
+	}
+	cp := *t
+	cp.cold = nil
+	cp.hotRows = 0
+	cp.rows = append([][][]byte(nil), t.rows...)
+	for _, b := range t.cold {
+		t.decompress(b, cp.rows)
+	}
+	return &cp
+}
+
+// thaw decompresses every compressed row of t in place, before rows are
+// changed or reordered.
+func (t *Table) thaw() {
+	for _, b := range t.cold {
+		t.decompress(b, t.rows)
+	}
+	t.cold = nil
+}
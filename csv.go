@@ -0,0 +1,181 @@
+// csv.go
+
+package tables
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+)
+
+// csvConfig holds the settings applied by CSVOption values.
+type csvConfig struct {
+	delimiter    rune
+	comment      rune
+	lazyQuotes   bool
+	trimSpace    bool
+	skipHeader   bool
+	headers      []string
+	sniffTypes   bool
+	detectHeader bool
+	schema       []Column
+	rejected     **Table
+}
+
+// CSVOption configures NewFromCSV and NewFromDelimited.
+type CSVOption func(*csvConfig)
+
+// CSVDelimiter sets the field delimiter (default ',').
+func CSVDelimiter(r rune) CSVOption {
+	return func(c *csvConfig) { c.delimiter = r }
+}
+
+// CSVComment makes lines beginning with r be ignored as comments.
+func CSVComment(r rune) CSVOption {
+	return func(c *csvConfig) { c.comment = r }
+}
+
+// CSVLazyQuotes relaxes quote handling: a quote may appear in an unquoted
+// field and a non-doubled quote may appear in a quoted field. Useful for
+// hand-written or sloppily exported files.
+func CSVLazyQuotes() CSVOption {
+	return func(c *csvConfig) { c.lazyQuotes = true }
+}
+
+// CSVTrimSpace ignores leading white space in fields.
+func CSVTrimSpace() CSVOption {
+	return func(c *csvConfig) { c.trimSpace = true }
+}
+
+// CSVSkipHeader discards the first record instead of using it as the header
+// row. Headers then come from CSVHeaders, or are named "Col 1".."Col N".
+func CSVSkipHeader() CSVOption {
+	return func(c *csvConfig) { c.skipHeader = true }
+}
+
+// CSVHeaders sets the table headers explicitly. The first record is then
+// treated as data unless CSVSkipHeader is also given.
+func CSVHeaders(headers ...string) CSVOption {
+	return func(c *csvConfig) { c.headers = headers }
+}
+
+// NewFromCSV reads a CSV stream and builds a table from it. By default the
+// first record is the header row and every following record becomes a data
+// row. Records are read one at a time, and records with too few or too many
+// fields are padded or cut to the header count like AddRow does; use
+// CSVSchema to reject them instead.
+//
+// Example:
+//
+//	f, _ := os.Open("users.csv")
+//	t, err := tables.NewFromCSV(f, tables.CSVDelimiter(';'))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	t.Print()
+func NewFromCSV(r io.Reader, opts ...CSVOption) (*Table, error) {
+	cfg := csvConfig{delimiter: ','}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	cr := csv.NewReader(r)
+	cr.Comma = cfg.delimiter
+	cr.Comment = cfg.comment
+	cr.LazyQuotes = cfg.lazyQuotes
+	cr.TrimLeadingSpace = cfg.trimSpace
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+
+	var t *Table
+	if cfg.headers != nil {
+		t = NewFromStrings(cfg.headers...)
+	}
+	first := true
+	cfg.startSchema()
+
+	add := func(rec []string, line int) {
+		if first {
+			first = false
+			if t == nil {
+				if cfg.skipHeader {
+					t = NewFromStrings(cfg.headerNames(len(rec))...)
+				} else {
+					t = NewFromStrings(rec...)
+					return
+				}
+			}
+			if cfg.skipHeader {
+				return
+			}
+		}
+		if !cfg.accept(line, rec) {
+			return
+		}
+
+		row := make([]any, len(rec))
+		for i, field := range rec {
+			row[i] = field
+		}
+		t.AddRow(row...)
+	}
+
+	// With CSVDetectHeader the first records are held back until they show
+	// whether the first one is a header.
+	detect := cfg.detectHeader && t == nil && !cfg.skipHeader
+	var held [][]string
+	var heldLines []int
+	release := func() {
+		if !looksLikeHeader(held) {
+			t = NewFromStrings(cfg.headerNames(len(held[0]))...)
+		}
+		for i, rec := range held {
+			add(rec, heldLines[i])
+		}
+		held, heldLines, detect = nil, nil, false
+	}
+
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("tables: reading CSV: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+
+		if detect {
+			held = append(held, slices.Clone(rec))
+			heldLines = append(heldLines, line)
+			if len(held) > sniffHeaderRows {
+				release()
+			}
+			continue
+		}
+		add(rec, line)
+	}
+	if len(held) > 0 {
+		release()
+	}
+
+	if t == nil {
+		t = NewFromStrings(cfg.headers...)
+	}
+	if cfg.sniffTypes {
+		t.sniffTypes()
+	}
+	return t, nil
+}
+
+// numberedHeaders returns "Col 1".."Col n".
+func numberedHeaders(n int) []string {
+	headers := make([]string, n)
+	for i := range headers {
+		headers[i] = "Col " + strconv.Itoa(i+1)
+	}
+	return headers
+}
@@ -0,0 +1,252 @@
+// dates.go
+
+package tables
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultDateLocale is the locale timestamps are shown in when their column
+// has none set with SetDateLocale. The empty default keeps the RFC 3339 form
+// of time.Time's MarshalText.
+var DefaultDateLocale = ""
+
+// dateLocale is how a locale writes dates, with layouts for values at
+// midnight and for values with a time of day.
+type dateLocale struct {
+	date, dateTime string
+}
+
+var (
+	dateISO = dateLocale{"2006-01-02", "2006-01-02 15:04:05"}
+	dateUS  = dateLocale{"Jan 2, 2006", "Jan 2, 2006 3:04 PM"}
+	dateGB  = dateLocale{"2 Jan 2006", "2 Jan 2006 15:04"}
+	dateDMY = dateLocale{"02/01/2006", "02/01/2006 15:04"}
+	dateDot = dateLocale{"02.01.2006", "02.01.2006 15:04"}
+	dateYMD = dateLocale{"2006/01/02", "2006/01/02 15:04"}
+)
+
+// dateLocales maps the names SetDateLocale accepts, lowercased, to their
+// layouts. A bare language stands for its most common region.
+var dateLocales = map[string]dateLocale{
+	"iso": dateISO,
+
+	"en": dateUS, "en-us": dateUS,
+	"en-gb": dateGB, "en-au": dateGB, "en-ie": dateGB, "en-nz": dateGB, "en-in": dateGB,
+	"en-ca": dateISO, "sv": dateISO, "sv-se": dateISO, "lt": dateISO,
+
+	"fr": dateDMY, "fr-fr": dateDMY, "es": dateDMY, "es-es": dateDMY, "es-mx": dateDMY,
+	"it": dateDMY, "it-it": dateDMY, "pt": dateDMY, "pt-br": dateDMY, "pt-pt": dateDMY,
+	"el": dateDMY, "el-gr": dateDMY,
+
+	"de": dateDot, "de-de": dateDot, "de-at": dateDot, "de-ch": dateDot,
+	"ru": dateDot, "ru-ru": dateDot, "pl": dateDot, "pl-pl": dateDot,
+	"cs": dateDot, "cs-cz": dateDot, "fi": dateDot, "fi-fi": dateDot,
+	"nb": dateDot, "nb-no": dateDot, "tr": dateDot, "tr-tr": dateDot,
+	"uk": dateDot, "uk-ua": dateDot,
+
+	"nl": {"02-01-2006", "02-01-2006 15:04"}, "nl-nl": {"02-01-2006", "02-01-2006 15:04"},
+	"ja": dateYMD, "ja-jp": dateYMD, "zh": dateYMD, "zh-cn": dateYMD, "zh-tw": dateYMD,
+	"ko": {"2006. 01. 02.", "2006. 01. 02. 15:04"}, "ko-kr": {"2006. 01. 02.", "2006. 01. 02. 15:04"},
+}
+
+// lookupDateLocale finds the layouts for a locale name such as "en-GB",
+// "de_DE", "fr" or "ISO", falling back from an unknown region to the
+// language.
+func lookupDateLocale(name string) (dateLocale, bool) {
+	name = strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+	if loc, ok := dateLocales[name]; ok {
+		return loc, true
+	}
+	lang, _, _ := strings.Cut(name, "-")
+	loc, ok := dateLocales[lang]
+	return loc, ok
+}
+
+// SetDateLocale sets how the timestamps in column col are shown:
+//
+//	"ISO"    2025-01-15
+//	"en-US"  Jan 15, 2025
+//	"en-GB"  15 Jan 2025
+//	"de-DE"  15.01.2025
+//	"fr-FR"  15/01/2025
+//	"ja-JP"  2025/01/15
+//
+// Locale names are BCP 47 style tags ("pt-BR", also accepted as "pt_BR");
+// a bare language picks its most common region and an unknown region falls
+// back to the language. Values with a time of day other than midnight get it
+// appended in the locale's usual form ("Jan 15, 2025 3:04 PM"). Month names
+// are English.
+//
+// time.Time values are stored in RFC 3339 form, offset included, and cells in
+// that form are written in the locale as they are rendered, after any
+// SetTimeZone conversion, so the locale applies to rows already added too.
+// Only the text output is affected; exports write the stored timestamps. An
+// unknown locale is ignored and an error wrapping ErrUnknownLocale recorded
+// for Err. An empty locale restores DefaultDateLocale.
+func (t *Table) SetDateLocale(col int, locale string) *Table {
+	if !t.checkColumn(col) {
+		return t
+	}
+	if locale == "" {
+		delete(t.dateLocales, col)
+		return t
+	}
+	loc, ok := lookupDateLocale(locale)
+	if !ok {
+		t.setErr(fmt.Errorf("%w: %q", ErrUnknownLocale, locale))
+		return t
+	}
+	if t.dateLocales == nil {
+		t.dateLocales = make(map[int]dateLocale)
+	}
+	t.dateLocales[col] = loc
+	return t
+}
+
+// dateLocaleOf returns the locale of column col, or DefaultDateLocale's, or
+// reports false if there is neither.
+func (t *Table) dateLocaleOf(col int) (dateLocale, bool) {
+	if loc, ok := t.dateLocales[col]; ok {
+		return loc, true
+	}
+	if DefaultDateLocale == "" {
+		return dateLocale{}, false
+	}
+	return lookupDateLocale(DefaultDateLocale)
+}
+
+// format writes tm in the locale, leaving out the time of day at midnight.
+func (loc dateLocale) format(tm time.Time) []byte {
+	layout := loc.dateTime
+	if tm.Hour() == 0 && tm.Minute() == 0 && tm.Second() == 0 && tm.Nanosecond() == 0 {
+		layout = loc.date
+	}
+	return tm.AppendFormat(nil, layout)
+}
+
+// showsTimes reports whether cells may be shown other than as stored, for
+// timeCell to work out.
+func (t *Table) showsTimes() bool {
+	return t.timeZones != nil || t.columnKinds != nil || t.dateLocales != nil || DefaultDateLocale != ""
+}
+
+// SetTimeZone shows the timestamps in column col in loc, such as time.Local
+// for the operator's own zone, without changing the stored values. Cells are
+// converted as they are rendered, so timestamps stored in UTC can be shown in
+// whatever zone the reader is in. A nil loc turns conversion off.
+//
+// Cells that parse as a date with a time of day are converted and written
+// back in the layout they had: RFC 3339 (the form time.Time values are
+// stored in) keeps its offset, so "2025-01-15T15:04:00Z" becomes
+// "2025-01-15T16:04:00+01:00" in Berlin, or is written in the column's
+// SetDateLocale form after the conversion. Text in other layouts without a
+// zone, including the locale's, is read as UTC. Dates without a time and
+// other text are left alone. Only the text output is converted; exports
+// write the stored values.
+func (t *Table) SetTimeZone(col int, loc *time.Location) *Table {
+	if !t.checkColumn(col) {
+		return t
+	}
+	if loc == nil {
+		delete(t.timeZones, col)
+		return t
+	}
+	if t.timeZones == nil {
+		t.timeZones = make(map[int]*time.Location)
+	}
+	t.timeZones[col] = loc
+	return t
+}
+
+// timeLayouts are the timestamp layouts cells are parsed with at render
+// time, besides the column's date locale. Parsing with RFC3339Nano also
+// accepts RFC 3339 without fractional seconds, and formatting with it drops
+// trailing zeros, matching time.Time's MarshalText.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// parseTime parses cell as a timestamp of column col, trying RFC 3339, the
+// form time.Time values are stored in with their offset, and then its date
+// locale's layout, and returns the layout that matched. With dates, values
+// without a time of day are accepted too. Layouts without a zone are read as
+// UTC.
+func (t *Table) parseTime(cell []byte, col int, dates bool) (time.Time, string, bool) {
+	if len(cell) < len("2 Jan 2006") {
+		return time.Time{}, "", false // no layout is shorter
+	}
+
+	s := string(cell)
+	if isRFC3339(cell) {
+		if tm, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			return tm, time.RFC3339Nano, true
+		}
+	}
+	dl, hasLocale := t.dateLocales[col]
+	if hasLocale {
+		if tm, err := time.Parse(dl.dateTime, s); err == nil {
+			return tm, dl.dateTime, true
+		}
+	}
+	for _, layout := range timeLayouts {
+		if tm, err := time.Parse(layout, s); err == nil {
+			return tm, layout, true
+		}
+	}
+	if !dates {
+		return time.Time{}, "", false
+	}
+	if hasLocale {
+		if tm, err := time.Parse(dl.date, s); err == nil {
+			return tm, dl.date, true
+		}
+	}
+	tm, err := time.Parse(time.DateOnly, s)
+	return tm, time.DateOnly, err == nil
+}
+
+// timeCell returns cell as column col shows it at render time: relative to
+// now for a KindRelativeTime column, otherwise converted to the column's time
+// zone and written in its date locale, each if it has one, and unchanged if
+// it isn't a timestamp or none applies.
+func (t *Table) timeCell(cell []byte, col int) []byte {
+	if t.columnKinds[col] == KindRelativeTime {
+		if tm, _, ok := t.parseTime(cell, col, true); ok {
+			return []byte(relativeTime(time.Since(tm)))
+		}
+		return cell
+	}
+
+	loc := t.timeZones[col]
+	if isRFC3339(cell) {
+		if dl, ok := t.dateLocaleOf(col); ok {
+			tm, err := time.Parse(time.RFC3339Nano, string(cell))
+			if err != nil {
+				return cell
+			}
+			if loc != nil {
+				tm = tm.In(loc)
+			}
+			return dl.format(tm)
+		}
+	}
+	if loc == nil {
+		return cell
+	}
+	if tm, layout, ok := t.parseTime(cell, col, false); ok {
+		return tm.In(loc).AppendFormat(nil, layout)
+	}
+	return cell
+}
+
+// isRFC3339 reports whether cell looks like an RFC 3339 timestamp, as a quick
+// check before parsing it.
+func isRFC3339(cell []byte) bool {
+	return len(cell) >= len("2006-01-02T15:04:05Z") && cell[4] == '-' && cell[10] == 'T'
+}
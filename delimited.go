@@ -0,0 +1,168 @@
+// delimited.go
+
+package tables
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// maxLineSize bounds a single input line for line-oriented importers.
+const maxLineSize = 1 << 20
+
+// NewFromDelimited builds a table from simple delimited text: TSV, pipe- or
+// semicolon-separated dumps, or the whitespace-aligned output of tools like
+// ps. The first line is the header row. Input is read line by line, so large
+// dumps are never held in memory as a whole before being added.
+//
+// Unlike NewFromCSV there is no quoting: every occurrence of delimiter splits
+// a field, and fields are trimmed of surrounding white space. Extra fields are
+// dropped and missing ones left empty, as with AddRow. Blank lines are
+// skipped.
+//
+// A delimiter of ' ' splits on runs of white space, and the last column takes
+// the rest of the line, so
+//
+//	ps aux | mytool
+//
+// keeps the COMMAND column intact even though it contains spaces.
+//
+// The header-related CSV options (CSVHeaders, CSVSkipHeader, CSVComment,
+// CSVDetectHeader), CSVSniffTypes and CSVSchema are honored; the quoting options have no
+// effect.
+func NewFromDelimited(r io.Reader, delimiter rune, opts ...CSVOption) (*Table, error) {
+	cfg := csvConfig{delimiter: delimiter}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	var t *Table
+	if cfg.headers != nil {
+		t = NewFromStrings(cfg.headers...)
+	}
+	first := true
+	cfg.startSchema()
+
+	add := func(line string, n int) {
+		limit := -1
+		if t != nil {
+			limit = len(t.headers)
+		}
+		fields := splitDelimited(line, delimiter, limit)
+
+		if first {
+			first = false
+			if t == nil {
+				if cfg.skipHeader {
+					t = NewFromStrings(cfg.headerNames(len(fields))...)
+				} else {
+					t = NewFromStrings(fields...)
+					return
+				}
+			}
+			if cfg.skipHeader {
+				return
+			}
+		}
+		if !cfg.accept(n, fields) {
+			return
+		}
+
+		row := make([]any, len(fields))
+		for i, f := range fields {
+			row[i] = f
+		}
+		t.AddRow(row...)
+	}
+
+	// With CSVDetectHeader the first lines are held back until they show
+	// whether the first one is a header.
+	detect := cfg.detectHeader && t == nil && !cfg.skipHeader
+	var held []string
+	var heldLines []int
+	release := func() {
+		records := make([][]string, len(held))
+		for i, line := range held {
+			records[i] = splitDelimited(line, delimiter, -1)
+		}
+		if !looksLikeHeader(records) {
+			t = NewFromStrings(cfg.headerNames(len(records[0]))...)
+		}
+		for i, line := range held {
+			add(line, heldLines[i])
+		}
+		held, heldLines, detect = nil, nil, false
+	}
+
+	n := 0
+	for sc.Scan() {
+		n++
+		line := strings.TrimRight(sc.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if cfg.comment != 0 && strings.HasPrefix(line, string(cfg.comment)) {
+			continue
+		}
+
+		if detect {
+			held = append(held, line)
+			heldLines = append(heldLines, n)
+			if len(held) > sniffHeaderRows {
+				release()
+			}
+			continue
+		}
+		add(line, n)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("tables: reading delimited input: %w", err)
+	}
+	if len(held) > 0 {
+		release()
+	}
+
+	if t == nil {
+		t = NewFromStrings(cfg.headers...)
+	}
+	if cfg.sniffTypes {
+		t.sniffTypes()
+	}
+	return t, nil
+}
+
+// splitDelimited splits line on delimiter, trimming white space around each
+// field. A ' ' delimiter splits on runs of white space into at most limit
+// fields (no limit when limit < 0), leaving the remainder in the last one.
+func splitDelimited(line string, delimiter rune, limit int) []string {
+	if delimiter != ' ' {
+		fields := strings.Split(line, string(delimiter))
+		for i, f := range fields {
+			fields[i] = strings.TrimSpace(f)
+		}
+		return fields
+	}
+
+	var fields []string
+	rest := strings.TrimSpace(line)
+	for rest != "" {
+		if limit > 0 && len(fields) == limit-1 {
+			fields = append(fields, rest)
+			break
+		}
+		end := strings.IndexFunc(rest, unicode.IsSpace)
+		if end < 0 {
+			fields = append(fields, rest)
+			break
+		}
+		fields = append(fields, rest[:end])
+		rest = strings.TrimLeftFunc(rest[end:], unicode.IsSpace)
+	}
+	return fields
+}
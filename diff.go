@@ -0,0 +1,151 @@
+// diff.go
+
+package tables
+
+import "strings"
+
+// diffPair holds the two columns compared by SetDiffColumns.
+type diffPair struct {
+	expected, actual int
+}
+
+// covers reports whether col is one of the two diff columns.
+func (d *diffPair) covers(col int) bool {
+	return col == d.expected || col == d.actual
+}
+
+// maxDiffCells bounds the LCS table used for character-level diffs. Cells
+// larger than this are compared as a whole instead, which keeps a single
+// pathological cell from turning a render into a quadratic memory spike.
+const maxDiffCells = 1 << 16
+
+// SetDiffColumns renders an inline, character-level diff between two columns
+// of every data row. Characters present only in the expected column (colA)
+// are shown in red as deletions; characters present only in the actual column
+// (colB) are shown in green as additions. Identical cells render unchanged.
+//
+// The diff is computed at render time on the ANSI-stripped cell text, so the
+// stored data is never modified and column widths are unaffected. Pass the
+// same index twice, or an out-of-range index, to disable diffing.
+//
+// Example:
+//
+//	tables.NewFromStrings("Case", "Expected", "Got").
+//	    SetDiffColumns(1, 2).
+//	    AddRow("trim", "hello", "hello ").
+//	    AddRow("upper", "HELLO", "HELlO").
+//	    Print()
+func (t *Table) SetDiffColumns(colA, colB int) *Table {
+	if !t.checkColumn(colA) || !t.checkColumn(colB) || colA == colB {
+		t.diff = nil
+		return t
+	}
+	t.diff = &diffPair{expected: colA, actual: colB}
+	return t
+}
+
+// diffCell returns the diff-highlighted version of cell col in row, or the
+// cell unchanged when col is not one of the diff columns.
+func (t *Table) diffCell(row [][]byte, col int) []byte {
+	if t.diff == nil || !t.diff.covers(col) {
+		return cellAt(row, col)
+	}
+
+	a := []rune(cellString(row, t.diff.expected))
+	b := []rune(cellString(row, t.diff.actual))
+	if string(a) == string(b) {
+		return cellAt(row, col)
+	}
+
+	if col == t.diff.expected {
+		return []byte(highlightRunes(a, diffKeep(a, b, true), t.palette().Removed, t.palette().RemovedSpace))
+	}
+	return []byte(highlightRunes(b, diffKeep(a, b, false), t.palette().Added, t.palette().AddedSpace))
+}
+
+// diffKeep marks which runes of one side belong to the longest common
+// subsequence of a and b. When forA is true the mask describes a, otherwise b.
+func diffKeep(a, b []rune, forA bool) []bool {
+	n := len(b)
+	if forA {
+		n = len(a)
+	}
+	keep := make([]bool, n)
+	if len(a)*len(b) > maxDiffCells {
+		return keep // too large, highlight the whole cell
+	}
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			if forA {
+				keep[i] = true
+			} else {
+				keep[j] = true
+			}
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return keep
+}
+
+// highlightRunes colors every run of runes whose keep flag is false. Runs
+// made only of whitespace use the background code instead, since a colored
+// space is otherwise invisible.
+func highlightRunes(runes []rune, keep []bool, fg, bg string) string {
+	var sb strings.Builder
+	mark := func(run []rune) {
+		if strings.TrimSpace(string(run)) == "" {
+			sb.WriteString(Colorize(string(run), bg))
+		} else {
+			sb.WriteString(Colorize(string(run), fg))
+		}
+	}
+	start := -1
+	for i, r := range runes {
+		if !keep[i] {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			mark(runes[start:i])
+			start = -1
+		}
+		sb.WriteRune(r)
+	}
+	if start >= 0 {
+		mark(runes[start:])
+	}
+	return sb.String()
+}
+
+// cellAt returns cell col of row, or nil if the row is too short.
+func cellAt(row [][]byte, col int) []byte {
+	if col < len(row) {
+		return row[col]
+	}
+	return nil
+}
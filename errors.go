@@ -0,0 +1,135 @@
+// errors.go
+
+package tables
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+)
+
+// Sentinel errors for failures callers may want to handle. They are wrapped
+// with details, so test for them with errors.Is:
+//
+//	if errors.Is(t.Err(), tables.ErrColumnOutOfRange) {
+//	    ...
+//	}
+var (
+	// ErrColumnOutOfRange reports a column index that is negative or not
+	// below the number of headers.
+	ErrColumnOutOfRange = errors.New("tables: column out of range")
+
+	// ErrRowOutOfRange reports a data row index that is negative or not below
+	// the number of data rows.
+	ErrRowOutOfRange = errors.New("tables: row out of range")
+
+	// ErrRowLengthMismatch reports a row with more values than the table has
+	// columns. The extra values are dropped.
+	ErrRowLengthMismatch = errors.New("tables: row length does not match header count")
+
+	// ErrInvalidStyle reports a border style that can't be rendered, or a
+	// style name ParseStyle doesn't know.
+	ErrInvalidStyle = errors.New("tables: invalid style")
+
+	// ErrInvalidTheme reports a Theme that can't be written as JSON or a
+	// JSON document that isn't a theme this release can read.
+	ErrInvalidTheme = errors.New("tables: invalid theme")
+
+	// ErrUnknownLocale reports a locale name SetDateLocale has no date
+	// format for.
+	ErrUnknownLocale = errors.New("tables: unknown locale")
+
+	// ErrInvalidTemplate reports a SetRowTemplate template that doesn't
+	// parse.
+	ErrInvalidTemplate = errors.New("tables: invalid row template")
+
+	// ErrAuditChain reports an audit log line whose hash doesn't follow from
+	// the lines before it, see VerifyAuditLog.
+	ErrAuditChain = errors.New("tables: audit log hash chain broken")
+
+	// ErrInterrupted is returned by PrintFullScreen when the user pressed
+	// Ctrl-C. The screen has been restored; the caller decides whether to
+	// exit.
+	ErrInterrupted = errors.New("tables: interrupted")
+)
+
+// Err returns the first error recorded by a chained call on the table, or nil.
+// Setters and AddRow return *Table so calls can be chained, which leaves no
+// room for an error result; instead a call that can't be carried out is
+// skipped (or, for long rows, truncated) and its error kept here:
+//
+//	t.SetAlign(7, tables.AlignRight).AddRow(a, b, c, d)
+//	if err := t.Err(); err != nil {
+//	    log.Println(err) // tables: column out of range: column 7, table has 3
+//	}
+func (t *Table) Err() error {
+	return t.err
+}
+
+// setErr records err unless an earlier error is already recorded.
+func (t *Table) setErr(err error) {
+	if t.err == nil {
+		t.err = err
+	}
+}
+
+// checkColumn reports whether col is a valid column index, recording
+// ErrColumnOutOfRange when it is not.
+func (t *Table) checkColumn(col int) bool {
+	if col >= 0 && col < len(t.headers) {
+		return true
+	}
+	t.setErr(columnError(col, len(t.headers)))
+	return false
+}
+
+// columnError returns ErrColumnOutOfRange for column col of a table with n
+// columns.
+func columnError(col, n int) error {
+	return fmt.Errorf("%w: column %d, table has %d", ErrColumnOutOfRange, col, n)
+}
+
+// checkRowLength records ErrRowLengthMismatch when n values were given for a
+// row of the table.
+func (t *Table) checkRowLength(n int) {
+	if n > len(t.headers) {
+		t.setErr(fmt.Errorf("%w: %d values, table has %d columns", ErrRowLengthMismatch, n, len(t.headers)))
+	}
+}
+
+// Validate reports whether the style can be rendered: every border rune must
+// be a printable character one column wide, as measured by RuneWidth, or
+// the borders would no longer line up with the cells (emoji and CJK
+// characters take two columns). It returns an error wrapping
+// ErrInvalidStyle otherwise, e.g. for a zero Style{}.
+func (s Style) Validate() error {
+	for pos := BorderTopLeft; pos <= BorderRightTee; pos++ {
+		r := s.Char(pos)
+		if !unicode.IsPrint(r) {
+			return fmt.Errorf("%w: %s is %q", ErrInvalidStyle, pos, r)
+		}
+		if w := RuneWidth(r); w != 1 {
+			return fmt.Errorf("%w: %s is %q, %d columns wide", ErrInvalidStyle, pos, r, w)
+		}
+	}
+	return nil
+}
+
+// ParseStyle returns the built-in style with the given name: "single",
+// "double", "rounded", "ascii" or "none". It is meant for command-line flags
+// and config files; unknown names return an error wrapping ErrInvalidStyle.
+func ParseStyle(name string) (Style, error) {
+	switch name {
+	case "single":
+		return StyleSingle, nil
+	case "double":
+		return StyleDouble, nil
+	case "rounded":
+		return StyleRounded, nil
+	case "ascii":
+		return StyleASCII, nil
+	case "none":
+		return StyleNone, nil
+	}
+	return Style{}, fmt.Errorf("%w: unknown style %q", ErrInvalidStyle, name)
+}
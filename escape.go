@@ -0,0 +1,136 @@
+// escape.go
+
+package tables
+
+import "bytes"
+
+// EscapePolicy controls what happens to escape sequences other than SGR
+// color/attribute codes (ESC [ ... m) found in cell content, such as cursor
+// saves, OSC window titles or hyperlinks. SGR codes are always kept.
+type EscapePolicy int
+
+const (
+	// EscapePassthrough writes non-SGR sequences to the output unchanged and
+	// counts them as zero width. Only use it for trusted content.
+	EscapePassthrough EscapePolicy = iota
+
+	// EscapeStrip removes non-SGR sequences from the rendered output.
+	EscapeStrip
+
+	// EscapeReject neutralizes non-SGR sequences by rendering them as visible
+	// caret notation ("^[]0;title^G"), so they are displayed rather than
+	// interpreted, and measured as the text they now are.
+	EscapeReject
+
+	// EscapeSanitize removes everything that lets cell content take control
+	// of the terminal: clipboard writes (OSC 52), window title changes, cursor
+	// movement and erasing, device control strings, and raw C0/C1 control
+	// characters such as carriage return and backspace. OSC 8 hyperlinks are
+	// removed as well, leaving their text, since a cell could show one
+	// address and link to another; the links SetAutoLink adds are not
+	// affected. Newlines and tabs become spaces. Colors are kept.
+	//
+	// This is the default for tables created with New and NewFromStrings, so
+	// rendering attacker-controlled strings is safe without extra setup.
+	EscapeSanitize
+)
+
+// SetEscapePolicy sets how non-SGR escape sequences in cells, headers and the
+// footer are handled at render time. Stored data is not modified; the policy
+// applies to both width measurement and output, so columns always line up.
+// The default is EscapeSanitize.
+func (t *Table) SetEscapePolicy(p EscapePolicy) *Table {
+	t.escapePolicy = p
+	return t
+}
+
+// applyEscapePolicy rewrites the non-SGR sequences in cell according to p.
+func applyEscapePolicy(cell []byte, p EscapePolicy) []byte {
+	if p == EscapeSanitize {
+		return sanitizeEscapes(cell)
+	}
+	if p == EscapePassthrough || bytes.IndexByte(cell, '\033') < 0 {
+		return cell
+	}
+
+	out := make([]byte, 0, len(cell))
+	for i := 0; i < len(cell); {
+		if cell[i] != '\033' {
+			out = append(out, cell[i])
+			i++
+			continue
+		}
+
+		n, kind := scanEscape(cell[i:])
+		switch {
+		case kind == escSGR:
+			out = append(out, cell[i:i+n]...)
+		case p == EscapeReject:
+			out = appendCaret(out, cell[i:i+n])
+		}
+		i += n
+	}
+	return out
+}
+
+// appendCaret appends seq to out with every C0 control byte (and DEL)
+// written in caret notation, e.g. ESC as "^[" and BEL as "^G".
+func appendCaret(out, seq []byte) []byte {
+	for _, c := range seq {
+		switch {
+		case c < 0x20:
+			out = append(out, '^', c+'@')
+		case c == 0x7F:
+			out = append(out, '^', '?')
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// sanitizeEscapes implements EscapeSanitize. cell is returned as is when it
+// holds no control characters at all.
+func sanitizeEscapes(cell []byte) []byte {
+	i := 0
+	for i < len(cell) && !isControlAt(cell, i) {
+		i++
+	}
+	if i == len(cell) {
+		return cell
+	}
+
+	out := make([]byte, i, len(cell))
+	copy(out, cell[:i])
+	for i < len(cell) {
+		switch c := cell[i]; {
+		case c == '\033':
+			n, kind := scanEscape(cell[i:])
+			if kind == escSGR {
+				out = append(out, cell[i:i+n]...)
+			}
+			i += n
+		case c == '\n' || c == '\t':
+			out = append(out, ' ')
+			i++
+		case c == 0xC2 && isControlAt(cell, i):
+			i += 2 // C1 control, e.g. U+009B which some terminals treat as CSI
+		case isControlAt(cell, i):
+			i++
+		default:
+			out = append(out, c)
+			i++
+		}
+	}
+	return out
+}
+
+// isControlAt reports whether a control character starts at b[i]: a C0
+// control or DEL, or a UTF-8 encoded C1 control (U+0080–U+009F).
+func isControlAt(b []byte, i int) bool {
+	c := b[i]
+	if c < 0x20 || c == 0x7F {
+		return true
+	}
+	return c == 0xC2 && i+1 < len(b) && b[i+1] >= 0x80 && b[i+1] <= 0x9F
+}
@@ -1,6 +1,8 @@
 package tables
 
 import (
+	"bytes"
+	"io"
 	"strings"
 )
 
@@ -9,6 +11,7 @@ import (
 // Column alignment and border style are not applied — those are terminal-only
 // concepts. Separator rows are skipped. The footer row, if set, is appended last.
 func (t *Table) ToCSV() string {
+	t = t.warm()
 	if len(t.headers) == 0 {
 		return ""
 	}
@@ -67,178 +70,189 @@ func csvField(s string) string {
 	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
 }
 
-// ToHTML returns a self-contained HTML <table> block.
-// ANSI sequences are stripped. Separator rows become <tr class="separator">
-// so you can style them with CSS. The footer, if set, goes in a <tfoot> block.
-func (t *Table) ToHTML() string {
+// WriteTSVTo writes the table to w as tab-separated values: one line per row,
+// header first and footer last, with ANSI sequences stripped and separator
+// rows skipped. TSV has no quoting, so tabs and line breaks inside cells are
+// written as spaces; every line then has exactly one field per column, which
+// is what awk, cut and spreadsheet paste expect.
+//
+// Example:
+//
+//	t.WriteTSVTo(os.Stdout) // | cut -f2
+func (t *Table) WriteTSVTo(w io.Writer) (int64, error) {
+	t = t.warm()
 	if len(t.headers) == 0 {
-		return ""
+		return 0, nil
 	}
 
-	var sb strings.Builder
+	buf := t.bufPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer t.bufPool.Put(buf)
 
-	sb.WriteString("<table>\n  <thead>\n    <tr>\n")
-	for i, h := range t.headers {
-		align := htmlAlign(t.aligns[i])
-		cell := htmlEscape(StripANSI(string(h)))
-		sb.WriteString("      <th style=\"text-align:")
-		sb.WriteString(align)
-		sb.WriteString("\">")
-		sb.WriteString(cell)
-		sb.WriteString("</th>\n")
+	t.tsvLine(buf, t.headers, false)
+	for i, row := range t.rows {
+		if t.rowKinds[i] == rowData {
+			t.tsvLine(buf, row, true)
+		}
 	}
-	sb.WriteString("    </tr>\n  </thead>\n")
-
 	if t.footer != nil {
-		sb.WriteString("  <tfoot>\n    <tr>\n")
-		for j := range t.headers {
-			align := htmlAlign(t.aligns[j])
-			var cell string
-			if j < len(t.footer) {
-				cell = htmlEscape(StripANSI(string(t.footer[j])))
-			}
-			sb.WriteString("      <td style=\"text-align:")
-			sb.WriteString(align)
-			sb.WriteString("\"><strong>")
-			sb.WriteString(cell)
-			sb.WriteString("</strong></td>\n")
-		}
-		sb.WriteString("    </tr>\n  </tfoot>\n")
+		t.tsvLine(buf, t.footer, false)
 	}
+	return buf.WriteTo(w)
+}
 
-	sb.WriteString("  <tbody>\n")
-	for i, row := range t.rows {
-		if t.rowKinds[i] == rowSeparator {
-			sb.WriteString("    <tr class=\"separator\"><td colspan=\"")
-			sb.WriteString(itoa(len(t.headers)))
-			sb.WriteString("\"></td></tr>\n")
+// tsvLine writes one TSV line. Data rows are truncated to the column max
+// widths, like ToCSV.
+func (t *Table) tsvLine(buf *bytes.Buffer, row [][]byte, data bool) {
+	for j := range t.headers {
+		if j > 0 {
+			buf.WriteByte('\t')
+		}
+		if j >= len(row) {
 			continue
 		}
-		sb.WriteString("    <tr>\n")
-		for j := range t.headers {
-			align := htmlAlign(t.aligns[j])
-			var cell string
-			if j < len(row) {
-				cell = StripANSI(string(row[j]))
-				if t.maxWidths[j] > 0 {
-					cell = TruncateToWidth(cell, t.maxWidths[j])
-				}
-			}
-			sb.WriteString("      <td style=\"text-align:")
-			sb.WriteString(align)
-			sb.WriteString("\">")
-			sb.WriteString(htmlEscape(cell))
-			sb.WriteString("</td>\n")
+		cell := StripANSI(string(row[j]))
+		if data && t.maxWidths[j] > 0 {
+			cell = TruncateToWidth(cell, t.maxWidths[j])
+		}
+		if strings.ContainsAny(cell, "\t\r\n") {
+			cell = tsvEscaper.Replace(cell)
 		}
-		sb.WriteString("    </tr>\n")
+		buf.WriteString(cell)
 	}
-	sb.WriteString("  </tbody>\n</table>")
+	buf.WriteByte('\n')
+}
 
-	return sb.String()
+// tsvEscaper turns the characters TSV can't hold in a field into spaces.
+var tsvEscaper = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+// ToHTML returns a self-contained HTML <table> block with ANSI sequences
+// stripped. It is equivalent to HTML with no options.
+func (t *Table) ToHTML() string {
+	return t.HTML()
 }
 
 // ToMarkdown returns the table in GitHub Flavored Markdown pipe-table format.
-// Alignment colons are placed in the separator row per the GFM spec.
-// ANSI sequences are stripped. AddSeparator rows are omitted — GFM has no
-// equivalent. The footer row, if set, is appended as a plain data row.
+// It is equivalent to Markdown.
 func (t *Table) ToMarkdown() string {
+	return t.Markdown()
+}
+
+// Markdown returns the table in GitHub Flavored Markdown pipe-table format,
+// ready to paste into a README or issue. Column alignments become the GFM
+// markers `:---`, `:---:` and `---:` in the delimiter row, and cells are padded
+// by display width so the source lines up too.
+//
+// ANSI sequences are stripped, pipes are escaped as `\|` and line breaks are
+// written as `<br>`. AddSeparator rows are omitted — GFM has no equivalent.
+// The footer row, if set, is appended as a plain data row.
+func (t *Table) Markdown() string {
+	t = t.warm()
 	if len(t.headers) == 0 {
 		return ""
 	}
 
-	colWidths := make([]int, len(t.headers))
+	var sb strings.Builder
+	t.writeMarkdown(&sb)
+	return sb.String()
+}
+
+// WriteMarkdownTo writes the Markdown form of the table to w. See Markdown.
+func (t *Table) WriteMarkdownTo(w io.Writer) (int64, error) {
+	t = t.warm()
+	if len(t.headers) == 0 {
+		return 0, nil
+	}
+
+	buf := t.bufPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer t.bufPool.Put(buf)
+
+	t.writeMarkdown(buf)
+	return buf.WriteTo(w)
+}
+
+// writeMarkdown renders the Markdown table into sb.
+func (t *Table) writeMarkdown(sb io.StringWriter) {
+	header := make([]string, len(t.headers))
 	for i, h := range t.headers {
-		colWidths[i] = len(StripANSI(string(h)))
+		header[i] = mdCell(h, 0)
 	}
+	var body [][]string
 	for i, row := range t.rows {
 		if t.rowKinds[i] == rowSeparator {
 			continue
 		}
-		for j := range t.headers {
-			if j < len(row) {
-				if w := len(StripANSI(string(row[j]))); w > colWidths[j] {
-					colWidths[j] = w
-				}
-			}
-		}
+		body = append(body, t.mdRow(row))
 	}
 	if t.footer != nil {
-		for j := range t.headers {
-			if j < len(t.footer) {
-				if w := len(StripANSI(string(t.footer[j]))); w > colWidths[j] {
-					colWidths[j] = w
-				}
-			}
-		}
+		body = append(body, t.mdRow(t.footer))
 	}
-	for i, mw := range t.maxWidths {
-		if mw > 0 && colWidths[i] > mw {
-			colWidths[i] = mw
-		}
+
+	colWidths := make([]int, len(t.headers))
+	for i, cell := range header {
+		colWidths[i] = max(3, StringWidthCustom(cell, t.widthFunc))
 	}
-	for i := range colWidths {
-		if colWidths[i] < 3 {
-			colWidths[i] = 3
+	for _, row := range body {
+		for j, cell := range row {
+			colWidths[j] = max(colWidths[j], StringWidthCustom(cell, t.widthFunc))
 		}
 	}
 
-	var sb strings.Builder
-
-	sb.WriteByte('|')
-	for i, h := range t.headers {
-		sb.WriteByte(' ')
-		sb.WriteString(mdPad(StripANSI(string(h)), colWidths[i], t.aligns[i]))
-		sb.WriteString(" |")
-	}
-	sb.WriteByte('\n')
+	t.mdLine(sb, header, colWidths)
 
-	sb.WriteByte('|')
+	sb.WriteString("|")
 	for i := range t.headers {
 		sb.WriteString(mdSeparator(colWidths[i], t.aligns[i]))
-		sb.WriteByte('|')
+		sb.WriteString("|")
 	}
-	sb.WriteByte('\n')
+	sb.WriteString("\n")
 
-	for i, row := range t.rows {
-		if t.rowKinds[i] == rowSeparator {
-			continue
-		}
-		sb.WriteByte('|')
-		for j := range t.headers {
-			var cell string
-			if j < len(row) {
-				cell = StripANSI(string(row[j]))
-				if t.maxWidths[j] > 0 {
-					cell = TruncateToWidth(cell, t.maxWidths[j])
-				}
-			}
-			sb.WriteByte(' ')
-			sb.WriteString(mdPad(cell, colWidths[j], t.aligns[j]))
-			sb.WriteString(" |")
-		}
-		sb.WriteByte('\n')
+	for _, row := range body {
+		t.mdLine(sb, row, colWidths)
 	}
+}
 
-	if t.footer != nil {
-		sb.WriteByte('|')
-		for j := range t.headers {
-			var cell string
-			if j < len(t.footer) {
-				cell = StripANSI(string(t.footer[j]))
-				if t.maxWidths[j] > 0 {
-					cell = TruncateToWidth(cell, t.maxWidths[j])
-				}
-			}
-			sb.WriteByte(' ')
-			sb.WriteString(mdPad(cell, colWidths[j], t.aligns[j]))
-			sb.WriteString(" |")
+// mdRow converts a data or footer row to escaped Markdown cells, applying
+// column max widths.
+func (t *Table) mdRow(row [][]byte) []string {
+	cells := make([]string, len(t.headers))
+	for j := range t.headers {
+		if j < len(row) {
+			cells[j] = mdCell(row[j], t.maxWidths[j])
 		}
-		sb.WriteByte('\n')
 	}
+	return cells
+}
 
-	return sb.String()
+// mdLine writes one padded Markdown table line.
+func (t *Table) mdLine(sb io.StringWriter, cells []string, colWidths []int) {
+	sb.WriteString("|")
+	for j, cell := range cells {
+		sb.WriteString(" ")
+		sb.WriteString(mdPad(cell, StringWidthCustom(cell, t.widthFunc), colWidths[j], t.aligns[j]))
+		sb.WriteString(" |")
+	}
+	sb.WriteString("\n")
 }
 
+// mdCell strips ANSI sequences from cell, truncates it to maxWidth (0 = no
+// limit) and escapes the characters that would break a pipe table.
+func mdCell(cell []byte, maxWidth int) string {
+	s := StripANSI(string(cell))
+	if maxWidth > 0 {
+		s = TruncateToWidth(s, maxWidth)
+	}
+	if strings.ContainsAny(s, "|\r\n") {
+		s = mdEscaper.Replace(s)
+	}
+	return s
+}
+
+// mdEscaper escapes pipes and turns line breaks into <br>, which GFM renders
+// inside table cells.
+var mdEscaper = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>", "\r", "<br>")
+
 func htmlAlign(a Align) string {
 	switch a {
 	case AlignCenter:
@@ -259,8 +273,7 @@ func htmlEscape(s string) string {
 	return s
 }
 
-func mdPad(s string, width int, align Align) string {
-	cur := len(s)
+func mdPad(s string, cur, width int, align Align) string {
 	if cur >= width {
 		return s
 	}
@@ -282,9 +295,9 @@ func mdSeparator(width int, align Align) string {
 	case AlignCenter:
 		return ":" + dashes + ":"
 	case AlignRight:
-		return dashes + ":"
+		return "-" + dashes + ":"
 	default:
-		return dashes + "-"
+		return ":" + dashes + "-"
 	}
 }
 
@@ -3,9 +3,11 @@
 package tables
 
 import (
+    "encoding"
     "fmt"
     "sort"
     "strconv"
+    "time"
 )
 
 // SortByColumn sorts the table's data rows by the values in the given column
@@ -20,29 +22,32 @@ import (
 // the column looks like a number, a numeric sort is used instead so that
 // "10" sorts after "9" rather than before it.
 func (t *Table) SortByColumn(col int, ascending bool) *Table {
-    if col < 0 || col >= len(t.headers) || len(t.rows) == 0 {
+    t.thaw()
+    if !t.checkColumn(col) || len(t.rows) == 0 {
         return t
     }
 
     // Strip separator rows first — their positions are meaningless post-sort.
-    cleanRows := make([][][]byte, 0, len(t.rows))
-    cleanKinds := make([]rowKind, 0, len(t.rows))
-    for i, row := range t.rows {
+    // The sort works on row indices so that every slice kept parallel to
+    // t.rows can be reordered in one go afterwards.
+    perm := make([]int, 0, len(t.rows))
+    for i := range t.rows {
         if t.rowKinds[i] == rowSeparator {
             continue
         }
-        cleanRows = append(cleanRows, row)
-        cleanKinds = append(cleanKinds, rowData)
+        perm = append(perm, i)
+    }
+    t.permuteRows(perm)
+    for i := range perm {
+        perm[i] = i
     }
-    t.rows = cleanRows
-    t.rowKinds = cleanKinds
 
     // Decide whether to use numeric or lexicographic comparison.
     numeric := isNumericColumn(t.rows, col)
 
-    sort.SliceStable(t.rows, func(i, j int) bool {
-        a := cellString(t.rows[i], col)
-        b := cellString(t.rows[j], col)
+    sort.SliceStable(perm, func(i, j int) bool {
+        a := cellString(t.rows[perm[i]], col)
+        b := cellString(t.rows[perm[j]], col)
 
         var less bool
         if numeric {
@@ -56,6 +61,7 @@ func (t *Table) SortByColumn(col int, ascending bool) *Table {
         }
         return !less
     })
+    t.permuteRows(perm)
 
     return t
 }
@@ -105,6 +111,7 @@ func (t *Table) SetFooter(values ...interface{}) *Table {
         return t
     }
 
+    t.checkRowLength(len(values))
     row := make([][]byte, len(t.headers))
     for i, val := range values {
         if i >= len(t.headers) {
@@ -124,6 +131,16 @@ func (t *Table) SetFooter(values ...interface{}) *Table {
             row[i] = strconv.AppendFloat(nil, v, 'f', -1, 64)
         case bool:
             row[i] = strconv.AppendBool(nil, v)
+        case time.Time:
+            row[i] = t.cellBytes(v)
+        case encoding.TextMarshaler:
+            if text, err := v.MarshalText(); err == nil {
+                row[i] = text
+            } else {
+                row[i] = []byte(fmt.Sprintf("%v", v))
+            }
+        case fmt.Stringer:
+            row[i] = []byte(v.String())
         default:
             row[i] = []byte(fmt.Sprintf("%v", v))
         }
@@ -132,6 +149,9 @@ func (t *Table) SetFooter(values ...interface{}) *Table {
         row[i] = []byte{}
     }
 
+    if t.nfc {
+        normalizeCells(row)
+    }
     t.footer = row
     return t
 }
@@ -0,0 +1,60 @@
+// filter.go
+
+package tables
+
+// SetFilter shows only the data rows keep reports true for, so one table can
+// be rendered with different subsets of its rows, such as just the failures,
+// without building another. keep is called with the stored cells of each data
+// row, one per column, on every render, and must not modify them. Rows left
+// out stay in the table: Rows, SetCell indices and the exporters still see
+// every row. A separator is shown only where it still separates two rows.
+// Pass nil to show all rows again.
+//
+// Example:
+//
+//	if *failedOnly {
+//	    t.SetFilter(func(row [][]byte) bool {
+//	        return string(row[2]) == "FAIL"
+//	    })
+//	}
+func (t *Table) SetFilter(keep func(row [][]byte) bool) *Table {
+	t.filter = keep
+	return t
+}
+
+// filtered returns t itself, or with SetFilter a copy holding only the rows
+// the filter keeps.
+func (t *Table) filtered() *Table {
+	if t.filter == nil {
+		return t
+	}
+
+	keep := make([]int, 0, len(t.rows))
+	for pos, kind := range t.rowKinds {
+		if kind == rowData {
+			if t.filter(t.rows[pos]) {
+				keep = append(keep, pos)
+			}
+			continue
+		}
+		if len(keep) > 0 && t.rowKinds[keep[len(keep)-1]] == rowData {
+			keep = append(keep, pos)
+		}
+	}
+	for len(keep) > 0 && t.rowKinds[keep[len(keep)-1]] != rowData {
+		keep = keep[:len(keep)-1]
+	}
+
+	cp := *t
+	cp.filter = nil
+	cp.permuteRows(keep)
+	return &cp
+}
+
+// shown returns the table to render: decompressed, filtered by SetFilter,
+// grouped by GroupBy, with the SetColumnAggregate summaries in the footer,
+// cut to SetMaxRows or to the page RenderPage renders, with the
+// AddSpacerColumn gutters and with the SetAutoIndex column, each if set.
+func (t *Table) shown() *Table {
+	return t.warm().filtered().validated().treed().grouped().aggregated().capped().templated().transformed().flagged().paged().striped().spaced().indexed()
+}
@@ -0,0 +1,234 @@
+// fit.go
+
+package tables
+
+// SetMinColumnWidth sets the narrowest a column is squeezed to when the
+// table is fitted to the terminal, as Live, Pinned and full-screen output
+// and RenderForPaste do. Columns narrower than n to begin with keep their
+// width. When the table doesn't fit even with every column at n, whole
+// columns are left out instead, lowest SetColumnPriority first, rather than
+// rendering columns too narrow to read. The default of 1 only leaves columns
+// out when nothing else helps.
+//
+// Example:
+//
+//	t.SetMinColumnWidth(5).SetColumnPriority(0, 10) // the name column goes last
+func (t *Table) SetMinColumnWidth(n int) *Table {
+	t.minWidth = max(n, 1)
+	return t
+}
+
+// SetColumnPriority sets how important column col is to keep when the table
+// is too wide for the terminal (see SetMinColumnWidth). Columns are left out
+// from the lowest priority up, the rightmost first among equal ones; every
+// column starts at priority 0. At least one column is always shown.
+func (t *Table) SetColumnPriority(col, priority int) *Table {
+	if !t.checkColumn(col) {
+		return t
+	}
+	if t.priority == nil {
+		t.priority = make(map[int]int)
+	}
+	t.priority[col] = priority
+	return t
+}
+
+// fitted returns the table to render for the terminal width set in
+// fitWidth: t itself, or a copy without the columns that don't fit at their
+// minimum width.
+func (t *Table) fitted() *Table {
+	if t.fitWidth <= 0 || len(t.headers) < 2 {
+		return t
+	}
+
+	natural := t.naturalWidths()
+	floor := max(t.minWidth, 1)
+	keep := make([]int, len(natural))
+	need := 1 // the closing border
+	for i, w := range natural {
+		keep[i] = i
+		need += min(w, floor) + 3
+	}
+	if need <= t.fitWidth {
+		return t
+	}
+
+	for len(keep) > 1 && need > t.fitWidth {
+		drop := len(keep) - 1
+		for k := len(keep) - 2; k >= 0; k-- {
+			if t.priority[keep[k]] < t.priority[keep[drop]] {
+				drop = k
+			}
+		}
+		need -= min(natural[keep[drop]], floor) + 3
+		keep = append(keep[:drop], keep[drop+1:]...)
+	}
+	return t.remap(keep)
+}
+
+// remap returns a copy of t for rendering whose column k shows column
+// from[k] of t, or is a new, empty column where from[k] is -1. Everything
+// kept per column moves along with it.
+func (t *Table) remap(from []int) *Table {
+	cp := *t
+	to := make([]int, len(t.headers)) // new index of each column, -1 if left out
+	for i := range to {
+		to[i] = -1
+	}
+	for k, col := range from {
+		if col >= 0 {
+			to[col] = k
+		}
+	}
+
+	pick := func(row [][]byte) [][]byte {
+		if row == nil {
+			return nil
+		}
+		out := make([][]byte, len(from))
+		for k, col := range from {
+			out[k] = []byte{}
+			if col >= 0 && col < len(row) {
+				out[k] = row[col]
+			}
+		}
+		return out
+	}
+	cp.headers = pick(t.headers)
+	cp.footer = pick(t.footer)
+	cp.rows = make([][][]byte, len(t.rows))
+	for pos, row := range t.rows {
+		cp.rows[pos] = pick(row)
+	}
+
+	cp.aligns = make([]Align, len(from))
+	cp.maxWidths = make([]int, len(from))
+	for k, col := range from {
+		if col >= 0 {
+			cp.aligns[k] = t.aligns[col]
+			cp.maxWidths[k] = t.maxWidths[col]
+		}
+	}
+	if t.locked != nil {
+		cp.locked = make([]int, len(from))
+		for k, col := range from {
+			if col >= 0 {
+				cp.locked[k] = t.locked[col]
+			}
+		}
+	}
+
+	cp.colColors = projectMap(t.colColors, to)
+	cp.autoLink = projectMap(t.autoLink, to)
+	cp.mergeCols = projectMap(t.mergeCols, to)
+	cp.dateLocales = projectMap(t.dateLocales, to)
+	cp.timeZones = projectMap(t.timeZones, to)
+	cp.columnKinds = projectMap(t.columnKinds, to)
+	cp.wrapCols = projectMap(t.wrapCols, to)
+	cp.priority = projectMap(t.priority, to)
+	if t.cellColors != nil {
+		cp.cellColors = make(map[rowcol]*Color, len(t.cellColors))
+		for key, c := range t.cellColors {
+			if k := to[key.col]; k >= 0 {
+				cp.cellColors[rowcol{key.row, k}] = c
+			}
+		}
+	}
+	if t.diff != nil {
+		cp.diff = nil
+		if e, a := to[t.diff.expected], to[t.diff.actual]; e >= 0 && a >= 0 {
+			cp.diff = &diffPair{e, a}
+		}
+	}
+
+	if t.rowSpans != nil || t.rowDown != nil {
+		cp.rowSpans, cp.rowDown = nil, nil
+		if t.rowSpans != nil {
+			cp.rowSpans = make([][]int, len(t.rowSpans))
+		}
+		if t.rowDown != nil {
+			cp.rowDown = make([][]int, len(t.rowDown))
+		}
+		for pos := range max(len(t.rowSpans), len(t.rowDown)) {
+			t.remapSpans(&cp, pos, from, to)
+		}
+	}
+	return &cp
+}
+
+// remapSpans fills in cp's column and row spans of the row at pos, and
+// moves the text of a spanned cell whose first column was left out to the
+// first column it still covers. New columns are plain cells, unless they fall
+// inside a span, which then covers them too.
+func (t *Table) remapSpans(cp *Table, pos int, from, to []int) {
+	spans := t.spansAt(pos)
+	var down []int
+	if pos < len(t.rowDown) {
+		down = t.rowDown[pos]
+	}
+
+	var newSpans, newDown []int
+	if spans != nil {
+		newSpans = make([]int, len(from))
+		for k, col := range from {
+			if col < 0 {
+				newSpans[k] = 1
+			}
+		}
+	}
+	if down != nil {
+		newDown = make([]int, len(from))
+	}
+	row := cp.rows[pos]
+	for col := 0; col < len(to); {
+		width := 1
+		if spans != nil {
+			width = max(spans[col], 1)
+		}
+		first, last := -1, -1 // new columns the cell still covers, with any new ones between
+		for c := col; c < col+width; c++ {
+			if to[c] >= 0 {
+				if first < 0 {
+					first = to[c]
+				}
+				last = to[c]
+			}
+		}
+		if first >= 0 {
+			if newSpans != nil {
+				newSpans[first] = last - first + 1
+				row[first] = t.rows[pos][col]
+				for k := first + 1; k <= last; k++ {
+					newSpans[k] = 0
+					row[k] = []byte{}
+				}
+			}
+			if newDown != nil {
+				newDown[first] = down[col]
+			}
+		}
+		col += width
+	}
+
+	if pos < len(cp.rowSpans) {
+		cp.rowSpans[pos] = newSpans
+	}
+	if newDown != nil {
+		cp.rowDown[pos] = newDown
+	}
+}
+
+// projectMap returns m re-keyed by column through to, leaving out columns
+// mapped to -1. nil stays nil.
+func projectMap[V any](m map[int]V, to []int) map[int]V {
+	if m == nil {
+		return nil
+	}
+	out := make(map[int]V, len(m))
+	for col, v := range m {
+		if col < len(to) && to[col] >= 0 {
+			out[to[col]] = v
+		}
+	}
+	return out
+}
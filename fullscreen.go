@@ -0,0 +1,196 @@
+// fullscreen.go
+
+package tables
+
+import (
+	"bytes"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+)
+
+// PrintFullScreen shows the table on the terminal's alternate screen, the way
+// pagers and editors do, and waits for q, Esc or Enter. The table is centered
+// when it is smaller than the terminal; when it is wider, columns are
+// narrowed to fit, and rows that don't fit are clipped. Resizing the terminal
+// lays the table out again for the new size. When the user closes it the
+// normal screen comes back exactly as it was, with nothing added to the
+// scrollback.
+//
+// Ctrl-C also closes the table and restores the screen; PrintFullScreen then
+// returns ErrInterrupted instead of letting the signal end the program, so
+// the caller can clean up and exit itself.
+//
+// Standard output must be a terminal. Where standard input is a terminal that
+// can't be put in raw mode (Windows consoles) only Enter closes the table. Its
+// line is read by one goroutine shared by every call, which stays blocked
+// reading standard input after Ctrl-C or an error closes the table: the next
+// line typed goes to the next PrintFullScreen rather than to the program, so
+// a program that reads standard input itself shouldn't after one returns
+// early. With standard input redirected it isn't read at all, and only Ctrl-C
+// closes the table.
+func (t *Table) PrintFullScreen() error {
+	if !isTerminal(os.Stdout) {
+		return errNoTerminal
+	}
+	cols, rows, ok := GetTerminalSize()
+	if !ok {
+		cols, rows = 80, 24
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	// Enter the alternate screen with the cursor hidden; undo both on the
+	// way out, whichever way that is.
+	out := os.Stdout
+	if _, err := out.WriteString("\033[?1049h\033[?25l"); err != nil {
+		return err
+	}
+	defer out.WriteString("\033[?25h\033[?1049l")
+
+	if _, err := out.Write(t.fullScreenFrame(cols, rows)); err != nil {
+		return err
+	}
+
+	resized := make(chan struct{}, 1)
+	defer notifyResize(out.Fd(), resized)()
+
+	var pressed <-chan struct{} // nil, never ready, with redirected input
+	restore, err := makeRaw(os.Stdin.Fd(), 1)
+	if err == nil {
+		keys := make(chan struct{})
+		stop := make(chan struct{})
+		finished := make(chan struct{})
+		go waitKey(keys, stop, finished)
+		pressed = keys
+		// The reader must be done before the terminal leaves raw mode, or a
+		// read still in progress would wait for a whole line.
+		defer func() {
+			close(stop)
+			<-finished
+			restore()
+		}()
+	} else if isTerminal(os.Stdin) {
+		pressed = enterPresses()
+	}
+
+	for {
+		select {
+		case <-pressed:
+			return nil
+		case <-interrupt:
+			return ErrInterrupted
+		case <-resized:
+			if c, r, ok := GetTerminalSize(); ok {
+				cols, rows = c, r
+			}
+			if _, err := out.Write(t.fullScreenFrame(cols, rows)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// waitKey reads standard input, in raw mode with a read timeout, until q, Esc
+// or Enter is pressed or stop is closed.
+func waitKey(pressed, stop chan struct{}, finished chan<- struct{}) {
+	defer close(finished)
+	b := make([]byte, 1)
+	for {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		if n, _ := os.Stdin.Read(b); n == 1 {
+			switch b[0] {
+			case 'q', 'Q', '\033', '\r', '\n':
+				close(pressed)
+				return
+			}
+		}
+	}
+}
+
+// enterLines is the reader behind enterPresses.
+var enterLines struct {
+	once sync.Once
+	ch   chan struct{}
+}
+
+// enterPresses returns a channel that receives once for each line read from
+// standard input, and is closed at the end of the input. The reader is
+// started on first use and shared by all later calls, since a blocked read
+// can't be stopped.
+func enterPresses() <-chan struct{} {
+	enterLines.once.Do(func() {
+		enterLines.ch = make(chan struct{})
+		go func() {
+			b := make([]byte, 1)
+			for {
+				n, err := os.Stdin.Read(b)
+				if err != nil {
+					close(enterLines.ch)
+					return
+				}
+				if n == 1 && b[0] == '\n' {
+					enterLines.ch <- struct{}{}
+				}
+			}
+		}()
+	})
+	return enterLines.ch
+}
+
+// fullScreenFrame returns the table drawn on a cleared cols×rows screen,
+// fitted to the width and centered or clipped, using absolute cursor
+// positioning.
+func (t *Table) fullScreenFrame(cols, rows int) []byte {
+	fitted := *t
+	fitted.fitWidth = cols
+	lines := splitLines(nil, []byte(fitted.view().String()))
+	width := 0
+	for _, line := range lines {
+		width = max(width, StringWidthBytesCustom(StripANSIBytes(line), t.widthFunc))
+	}
+	top := max((rows-len(lines))/2, 0)
+	left := max((cols-width)/2, 0)
+
+	var buf bytes.Buffer
+	buf.WriteString("\033[2J")
+	for i, line := range lines[:min(len(lines), rows)] {
+		buf.WriteString("\033[")
+		buf.WriteString(strconv.Itoa(top + i + 1))
+		buf.WriteByte(';')
+		buf.WriteString(strconv.Itoa(left + 1))
+		buf.WriteByte('H')
+		buf.Write(clipLine(line, cols, t.widthFunc))
+	}
+	return buf.Bytes()
+}
+
+// clipLine cuts line to at most width terminal cells. Escape sequences don't
+// count toward the width; if any are cut off, colors are reset at the end.
+func clipLine(line []byte, width int, widthFunc WidthFunc) []byte {
+	w := 0
+	for i := 0; i < len(line); {
+		if line[i] == 0x1b {
+			n, _ := scanEscape(line[i:])
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRune(line[i:])
+		if w += widthFunc(r); w > width {
+			if bytes.IndexByte(line[i:], 0x1b) >= 0 {
+				return append(line[:i:i], "\033[0m"...)
+			}
+			return line[:i]
+		}
+		i += size
+	}
+	return line
+}
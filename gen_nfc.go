@@ -0,0 +1,258 @@
+// gen_nfc.go
+
+//go:build ignore
+
+// gen_nfc generates nfc_tables.go from the Unicode Character Database. Run it
+// with go generate, which fetches the data of the version in nfcVersion:
+//
+//	go generate ./...
+//
+// or point it at a directory holding UnicodeData.txt and
+// CompositionExclusions.txt:
+//
+//	go run gen_nfc.go -ucd /path/to/ucd
+//
+// With -test, NormalizationTest.txt is saved to testdata as well, for the
+// conformance test in nfc_test.go.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// nfcVersion is the Unicode version the tables are generated from.
+const nfcVersion = "14.0.0"
+
+var (
+	ucd     = flag.String("ucd", "https://www.unicode.org/Public/"+nfcVersion+"/ucd", "URL or directory of the Unicode Character Database")
+	out     = flag.String("o", "nfc_tables.go", "output file")
+	getTest = flag.Bool("test", false, "also save NormalizationTest.txt to testdata")
+)
+
+// pair is a canonical decomposition of c into a followed by b (b is 0 for a
+// singleton).
+type pair struct {
+	c, a, b rune
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("gen_nfc: ")
+	flag.Parse()
+
+	ccc := map[rune]uint8{}
+	var decomps []pair
+	parseFile("UnicodeData.txt", func(fields []string) {
+		r := parseRune(fields[0])
+		class, err := strconv.ParseUint(fields[3], 10, 8)
+		if err != nil {
+			log.Fatalf("UnicodeData.txt: %X: combining class %q", r, fields[3])
+		}
+		if class != 0 {
+			ccc[r] = uint8(class)
+		}
+		// Compatibility decompositions start with a <tag>; NFC ignores them.
+		d := strings.Fields(fields[5])
+		if len(d) == 0 || strings.HasPrefix(d[0], "<") {
+			return
+		}
+		p := pair{c: r, a: parseRune(d[0])}
+		switch len(d) {
+		case 1:
+		case 2:
+			p.b = parseRune(d[1])
+		default:
+			log.Fatalf("UnicodeData.txt: %X: canonical decomposition of %d runes", r, len(d))
+		}
+		decomps = append(decomps, p)
+	})
+
+	excluded := map[rune]bool{}
+	parseFile("CompositionExclusions.txt", func(fields []string) {
+		excluded[parseRune(fields[0])] = true
+	})
+
+	// NFC recombines a decomposition unless it is a singleton, a
+	// non-starter decomposition or listed in CompositionExclusions.txt
+	// (Unicode Standard Annex #15, "Full Composition Exclusion").
+	var composing, decomposing []pair
+	for _, p := range decomps {
+		if p.b == 0 || ccc[p.c] != 0 || ccc[p.a] != 0 || excluded[p.c] {
+			decomposing = append(decomposing, p)
+		} else {
+			composing = append(composing, p)
+		}
+	}
+	sort.Slice(composing, func(i, j int) bool { return composing[i].c < composing[j].c })
+	sort.Slice(decomposing, func(i, j int) bool { return decomposing[i].c < decomposing[j].c })
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `// nfc_tables.go
+
+// Code generated by gen_nfc.go; DO NOT EDIT.
+
+package tables
+
+// Unicode %s data for NFC normalization, derived from UnicodeData.txt and
+// CompositionExclusions.txt. Hangul syllables are handled algorithmically and
+// are not listed.
+
+// nfcPair is a canonical decomposition of c into a followed by b (b is 0 for
+// a singleton decomposition).
+type nfcPair struct {
+	c, a, b rune
+}
+
+// nfcComposing lists the primary composites: decompositions that NFC
+// recombines.
+var nfcComposing = [...]nfcPair{
+`, nfcVersion)
+	writePairs(&b, composing)
+	b.WriteString(`}
+
+// nfcDecomposing lists the canonical decompositions that NFC does not
+// recombine: singletons, composition exclusions and non-starter
+// decompositions.
+var nfcDecomposing = [...]nfcPair{
+`)
+	writePairs(&b, decomposing)
+	b.WriteString(`}
+
+// cccRange gives the canonical combining class of the runes lo..hi. Runes not
+// covered have class 0.
+type cccRange struct {
+	lo, hi rune
+	class  uint8
+}
+
+// nfcCombiningClasses lists every rune with a non-zero canonical combining
+// class, sorted by lo.
+var nfcCombiningClasses = [...]cccRange{
+`)
+	writeClasses(&b, ccc)
+	b.WriteString("}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+
+	if *getTest {
+		data, err := readAll("NormalizationTest.txt")
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join("testdata", "NormalizationTest.txt"), data, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// writePairs writes ps as composite literals, four to a line.
+func writePairs(w io.Writer, ps []pair) {
+	for i, p := range ps {
+		sep := " "
+		if i%4 == 0 {
+			sep = "\t"
+		}
+		fmt.Fprintf(w, "%s{0x%04X, 0x%04X, 0x%04X},", sep, p.c, p.a, p.b)
+		if i%4 == 3 || i == len(ps)-1 {
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+// writeClasses writes the runes of ccc as ranges of consecutive runes with
+// the same class, four to a line.
+func writeClasses(w io.Writer, ccc map[rune]uint8) {
+	runes := make([]rune, 0, len(ccc))
+	for r := range ccc {
+		runes = append(runes, r)
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+
+	n := 0
+	for i := 0; i < len(runes); {
+		j := i + 1
+		for j < len(runes) && runes[j] == runes[j-1]+1 && ccc[runes[j]] == ccc[runes[i]] {
+			j++
+		}
+		sep := " "
+		if n%4 == 0 {
+			sep = "\t"
+		}
+		fmt.Fprintf(w, "%s{0x%04X, 0x%04X, %d},", sep, runes[i], runes[j-1], ccc[runes[i]])
+		n++
+		if n%4 == 0 || j == len(runes) {
+			fmt.Fprintln(w)
+		}
+		i = j
+	}
+}
+
+// parseFile calls fn with the ';'-separated, trimmed fields of each data line
+// of the UCD file name, leaving out comments and blank lines.
+func parseFile(name string, fn func(fields []string)) {
+	data, err := readAll(name)
+	if err != nil {
+		log.Fatal(err)
+	}
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line, _, _ := strings.Cut(sc.Text(), "#")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(line, ";")
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		fn(fields)
+	}
+	if err := sc.Err(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readAll reads the UCD file name from the directory or URL in -ucd.
+func readAll(name string) ([]byte, error) {
+	if !strings.HasPrefix(*ucd, "http://") && !strings.HasPrefix(*ucd, "https://") {
+		return os.ReadFile(filepath.Join(*ucd, name))
+	}
+	resp, err := http.Get(*ucd + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", resp.Request.URL, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// parseRune parses a code point written in hex.
+func parseRune(s string) rune {
+	r, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		log.Fatalf("bad code point %q", s)
+	}
+	return rune(r)
+}
@@ -0,0 +1,136 @@
+// gotest.go
+
+package tables
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// testEvent mirrors the fields of cmd/test2json's TestEvent that the
+// summarizer needs.
+type testEvent struct {
+	Action  string
+	Package string
+	Test    string
+	Elapsed float64
+}
+
+// packageSummary accumulates the results for one package.
+type packageSummary struct {
+	name                    string
+	status                  string // "pass", "fail", "skip" or "" while running
+	passed, failed, skipped int
+	elapsed                 float64
+}
+
+// FromGoTestJSON reads `go test -json` output from r and returns a table with
+// one row per package: status, passed/failed/skipped test counts and elapsed
+// time. Statuses are colored (PASS green, FAIL red, SKIP yellow) and a footer
+// row holds the totals, with FAIL if any package failed, even one that
+// failed to build.
+//
+// Events are decoded one at a time as they arrive, so r can be a pipe from a
+// running `go test` process. Packages appear in the order they were first
+// seen; a package that never reported a final result is shown as RUNNING.
+//
+// Example:
+//
+//	t, err := tables.FromGoTestJSON(os.Stdin) // go test -json ./... | mytool
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	t.Print()
+func FromGoTestJSON(r io.Reader) (*Table, error) {
+	dec := json.NewDecoder(r)
+	byName := make(map[string]*packageSummary)
+	var order []*packageSummary
+
+	for {
+		var ev testEvent
+		if err := dec.Decode(&ev); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("tables: decoding go test -json output: %w", err)
+		}
+		if ev.Package == "" {
+			continue
+		}
+
+		pkg, ok := byName[ev.Package]
+		if !ok {
+			pkg = &packageSummary{name: ev.Package}
+			byName[ev.Package] = pkg
+			order = append(order, pkg)
+		}
+
+		switch ev.Action {
+		case "pass", "fail", "skip":
+		default:
+			continue // run, output, pause, cont, bench, ...
+		}
+
+		if ev.Test == "" {
+			pkg.status = ev.Action
+			pkg.elapsed = ev.Elapsed
+			continue
+		}
+		switch ev.Action {
+		case "pass":
+			pkg.passed++
+		case "fail":
+			pkg.failed++
+		case "skip":
+			pkg.skipped++
+		}
+	}
+
+	t := NewFromStrings("Package", "Status", "Passed", "Failed", "Skipped", "Elapsed").
+		SetHeaderColor(NewColor().WithStyle(Bold)).
+		SetAlign(1, AlignCenter).
+		SetAlign(2, AlignRight).
+		SetAlign(3, AlignRight).
+		SetAlign(4, AlignRight).
+		SetAlign(5, AlignRight)
+
+	var total packageSummary
+	overall := "pass"
+	for _, pkg := range order {
+		t.AddRow(pkg.name, goTestStatus(pkg.status), pkg.passed, pkg.failed, pkg.skipped, formatElapsed(pkg.elapsed))
+		total.passed += pkg.passed
+		total.failed += pkg.failed
+		total.skipped += pkg.skipped
+		total.elapsed += pkg.elapsed
+		// A package can fail without a failing test: a build error, or a
+		// panic in init or TestMain.
+		if pkg.failed > 0 || pkg.status == "fail" {
+			overall = "fail"
+		}
+	}
+
+	t.SetFooter("Total", goTestStatus(overall), total.passed, total.failed, total.skipped, formatElapsed(total.elapsed))
+	return t, nil
+}
+
+// goTestStatus returns the colored label for a go test action.
+func goTestStatus(action string) string {
+	switch action {
+	case "pass":
+		return Success("PASS")
+	case "fail":
+		return Error("FAIL")
+	case "skip":
+		return Warning("SKIP")
+	default:
+		return Info("RUNNING")
+	}
+}
+
+// formatElapsed formats seconds the way go test does ("0.012s").
+func formatElapsed(sec float64) string {
+	return strconv.FormatFloat(sec, 'f', 3, 64) + "s"
+}
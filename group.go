@@ -0,0 +1,200 @@
+// group.go
+
+package tables
+
+import (
+	"fmt"
+	"slices"
+)
+
+// GroupBy groups the data rows by their value in column col when the table
+// is rendered. Each group starts with a banner row holding the value, like
+// one added with AddSection, and its rows follow in their current order with
+// the value left out of col. Groups appear in the order their values first
+// occur, so sort the table first to order them. Values are compared with ANSI
+// sequences ignored. With SetColumnAggregate, each group ends with a
+// "Subtotal" row summarizing its rows, and the footer still summarizes the
+// whole table.
+//
+// Separators and AddSection banners are dropped from the rendered table, and
+// SetAutoIndex numbers only the grouped rows. Like SetFilter, grouping only
+// changes what is rendered: Rows, SetCell indices and the exporters see the
+// table as it was added. Banners take the SetSectionColor color and subtotals
+// the footer color. Pass -1 to turn grouping off, and see SetGroupLimit to
+// cap the rows shown per group.
+//
+// Example:
+//
+//	t.SortBy(0, true).
+//	    GroupBy(0).
+//	    SetColumnAggregate(2, tables.AggSum)
+//
+//	├──────────┴────────┴──────┤
+//	│ EU                       │
+//	├──────────┬────────┬──────┤
+//	│          │ Widget │ 12   │
+//	│          │ Gadget │ 8    │
+//	├──────────┼────────┼──────┤
+//	│ Subtotal │        │ 20   │
+//	├──────────┴────────┴──────┤
+//	│ US                       │
+func (t *Table) GroupBy(col int) *Table {
+	if col == -1 {
+		t.groupCol = -1
+		return t
+	}
+	if !t.checkColumn(col) {
+		return t
+	}
+	t.groupCol = col
+	return t
+}
+
+// SetGroupLimit shows at most n rows of each GroupBy group, in their current
+// order, followed by a line counting the rest:
+//
+//	│          │ Widget  │ 12    │
+//	│          │ Gadget  │ 8     │
+//	│ … 14 more in this group   │
+//
+// The line spans the table and is drawn in the theme's muted color.
+// Subtotals and the footer still cover every row, so the report stays
+// bounded without its numbers changing. n <= 0 shows every row again.
+func (t *Table) SetGroupLimit(n int) *Table {
+	t.groupLimit = max(n, 0)
+	return t
+}
+
+// grouped returns t itself, or with GroupBy a copy with the rows in groups
+// behind their banners, followed by their subtotals.
+func (t *Table) grouped() *Table {
+	if t.groupCol < 0 {
+		return t
+	}
+	col := t.groupCol
+
+	// Positions of each group's rows, groups in order of first appearance.
+	var keys []string
+	groups := make(map[string][]int)
+	for pos, kind := range t.rowKinds {
+		if kind != rowData || t.isAux(pos) {
+			continue
+		}
+		key := cellString(t.rows[pos], col)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], pos)
+	}
+
+	cp := *t
+	cp.groupCol = -1
+	cp.rows, cp.rowKinds, cp.rowASCII = nil, nil, nil
+	cp.rowSpans, cp.rowDown, cp.rowAux, cp.rowParent = nil, nil, nil, nil // spans down the rows would cross groups
+	cp.rowColors, cp.cellColors = nil, nil
+	cp.dirtyAll = true
+	if len(t.aggregates) > 0 {
+		// The footer summarizes every row, those SetGroupLimit leaves out too.
+		cp.footer, cp.aggregates = t.aggregated().footer, nil
+	}
+
+	// Data row index in t of each position, and in cp of each row of t.
+	before := make([]int, len(t.rows))
+	n := 0
+	for pos, kind := range t.rowKinds {
+		if kind == rowData {
+			before[pos] = n
+			n++
+		}
+	}
+	moved := make(map[int]int, n)
+
+	idx := 0 // next data row index in cp
+	add := func(row [][]byte, ascii bool, spans []int, aux bool) {
+		pos := len(cp.rows)
+		cp.rows = append(cp.rows, row)
+		cp.rowKinds = append(cp.rowKinds, rowData)
+		cp.rowASCII = append(cp.rowASCII, ascii)
+		cp.setSpans(pos, spans)
+		if aux {
+			cp.setAux(pos)
+		}
+		idx++
+	}
+	blank := func() [][]byte {
+		row := make([][]byte, len(t.headers))
+		for i := range row {
+			row[i] = []byte{}
+		}
+		return row
+	}
+	var banners, overflows, subtotals []int // data row indices in cp
+
+	for g, key := range keys {
+		members := groups[key]
+		if g > 0 {
+			cp.AddSeparator()
+		}
+
+		banner := blank()
+		if v := cellAt(t.rows[members[0]], col); v != nil {
+			banner[0] = v
+		}
+		spans := make([]int, len(t.headers))
+		spans[0] = len(t.headers)
+		banners = append(banners, idx)
+		add(banner, t.rowASCII[members[0]], spans, true)
+		cp.AddSeparator()
+
+		for k, pos := range members {
+			if t.groupLimit > 0 && k == t.groupLimit {
+				more := blank()
+				more[0] = fmt.Appendf(nil, "… %d more in this group", len(members)-k)
+				overflows = append(overflows, idx)
+				add(more, false, spans, true)
+				break
+			}
+			row := slices.Clone(t.rows[pos])
+			row[col] = []byte{}
+			moved[before[pos]] = idx
+			add(row, t.rowASCII[pos], t.spansAt(pos), false)
+		}
+
+		if len(t.aggregates) > 0 {
+			subtotal := blank()
+			subtotal[col] = []byte("Subtotal")
+			for c, agg := range t.aggregates {
+				subtotal[c] = t.aggregate(members, c, agg)
+			}
+			cp.AddSeparator()
+			subtotals = append(subtotals, idx)
+			add(subtotal, rowIsASCII(subtotal), nil, true)
+		}
+	}
+
+	for row, c := range t.rowColors {
+		if r, ok := moved[row]; ok {
+			cp.SetRowColor(r, c)
+		}
+	}
+	for key, c := range t.cellColors {
+		if r, ok := moved[key.row]; ok && key.col != col {
+			cp.SetCellColor(r, key.col, c)
+		}
+	}
+	for _, r := range banners {
+		if t.sectionColor != nil {
+			cp.SetRowColor(r, t.sectionColor)
+		}
+	}
+	muted := NewColor().WithStyle(t.palette().Muted)
+	for _, r := range overflows {
+		cp.SetRowColor(r, muted)
+	}
+	for _, r := range subtotals {
+		if t.footerColor != nil {
+			cp.SetRowColor(r, t.footerColor)
+		}
+	}
+	return &cp
+}
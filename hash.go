@@ -0,0 +1,76 @@
+// hash.go
+
+package tables
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+)
+
+// RowHash returns a 64-bit FNV-1a hash of the cells of data row i, for
+// callers caching work per row. Cells are hashed in NFC, whether or not
+// SetNormalizeNFC is on, and each is length-prefixed, so rows hash alike
+// exactly when their cells read the same: ("ab", "c") and ("a", "bc") don't.
+// Colors set with SetRowColor and other display settings are not part of
+// the hash; ANSI sequences stored in a cell are. An out-of-range i records
+// ErrRowOutOfRange and returns 0.
+func (t *Table) RowHash(i int) uint64 {
+	t = t.warm()
+	pos := t.rowPos(i)
+	if pos < 0 {
+		return 0
+	}
+	return t.rowHash(pos)
+}
+
+// Changed returns the indices of the data rows of t that differ from the
+// row at the same index in prev, such as the table built on the previous
+// refresh, in order. Rows beyond the end of prev count as changed; rows
+// removed since aren't reported. A nil prev reports every row.
+//
+// Example:
+//
+//	next := buildTable(stats)
+//	for _, i := range next.Changed(prev) {
+//	    notify(next.Rows()[i])
+//	}
+//	prev = next
+func (t *Table) Changed(prev *Table) []int {
+	t = t.warm()
+	var old []uint64
+	if prev != nil {
+		old = prev.warm().rowHashes()
+	}
+	var changed []int
+	for i, h := range t.rowHashes() {
+		if i >= len(old) || h != old[i] {
+			changed = append(changed, i)
+		}
+	}
+	return changed
+}
+
+// rowHashes returns the RowHash of every data row, in order.
+func (t *Table) rowHashes() []uint64 {
+	hashes := make([]uint64, 0, len(t.rows))
+	for pos, kind := range t.rowKinds {
+		if kind == rowData {
+			hashes = append(hashes, t.rowHash(pos))
+		}
+	}
+	return hashes
+}
+
+// rowHash hashes the row at pos in t.rows; see RowHash.
+func (t *Table) rowHash(pos int) uint64 {
+	h := fnv.New64a()
+	var n [binary.MaxVarintLen64]byte
+	for _, cell := range t.rows[pos] {
+		if !t.rowASCII[pos] && !t.nfc {
+			cell = normalizeNFC(cell)
+		}
+		h.Write(binary.AppendUvarint(n[:0], uint64(len(cell))))
+		h.Write(cell)
+	}
+	return h.Sum64()
+}
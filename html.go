@@ -0,0 +1,431 @@
+// html.go
+
+package tables
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// htmlConfig holds the settings applied by HTMLOption values.
+type htmlConfig struct {
+	tableClass string
+	colClasses map[int]string
+	ansi       bool
+}
+
+// HTMLOption configures HTML.
+type HTMLOption func(*htmlConfig)
+
+// HTMLTableClass sets the class attribute of the <table> element.
+func HTMLTableClass(class string) HTMLOption {
+	return func(c *htmlConfig) { c.tableClass = class }
+}
+
+// HTMLColumnClass sets the class attribute of every <th> and <td> in column
+// col (0-indexed), e.g. "num" for a column a stylesheet right-aligns in a
+// monospace font.
+func HTMLColumnClass(col int, class string) HTMLOption {
+	return func(c *htmlConfig) {
+		if c.colClasses == nil {
+			c.colClasses = make(map[int]string)
+		}
+		c.colClasses[col] = class
+	}
+}
+
+// HTMLANSIStyles converts colors instead of stripping them: SGR sequences in
+// cells, along with the table's own header, footer, row, column and cell
+// colors, become <span style="..."> elements with equivalent inline CSS
+// (color, background-color, font-weight, font-style, text-decoration). Tables
+// colored for the terminal then look the same on a web dashboard.
+func HTMLANSIStyles() HTMLOption {
+	return func(c *htmlConfig) { c.ansi = true }
+}
+
+// HTML returns a self-contained HTML <table> block with the title (if set)
+// in <caption>, the headers in <thead>, the footer (if set) in <tfoot> and
+// the data rows in <tbody>. Each cell carries its column alignment as an
+// inline text-align style. Separator rows become <tr class="separator"> so
+// you can style them with CSS. Cell text is HTML-escaped.
+//
+// By default ANSI sequences are stripped; options add class names and keep
+// colors as inline styles. Rows get links from SetRowLink.
+//
+// Example:
+//
+//	html := t.HTML(
+//	    tables.HTMLTableClass("report"),
+//	    tables.HTMLColumnClass(2, "num"),
+//	    tables.HTMLANSIStyles(),
+//	)
+func (t *Table) HTML(opts ...HTMLOption) string {
+	t = t.warm()
+	if len(t.headers) == 0 {
+		return ""
+	}
+
+	var cfg htmlConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("<table")
+	writeClassAttr(&sb, cfg.tableClass)
+	sb.WriteString(">\n")
+	if t.title != nil {
+		sb.WriteString("  <caption>")
+		sb.WriteString(htmlEscape(StripANSI(string(t.title))))
+		sb.WriteString("</caption>\n")
+	}
+	sb.WriteString("  <thead>\n    <tr>\n")
+	for i, h := range t.headers {
+		t.writeHTMLCell(&sb, &cfg, "th", i, 1, h, t.headerColor, false, "")
+	}
+	sb.WriteString("    </tr>\n  </thead>\n")
+
+	if t.footer != nil {
+		sb.WriteString("  <tfoot>\n    <tr>\n")
+		for j := range t.headers {
+			var cell []byte
+			if j < len(t.footer) {
+				cell = t.footer[j]
+			}
+			t.writeHTMLCell(&sb, &cfg, "td", j, 1, cell, t.footerColor, true, "")
+		}
+		sb.WriteString("    </tr>\n  </tfoot>\n")
+	}
+
+	sb.WriteString("  <tbody>\n")
+	dataIdx := 0
+	for i, row := range t.rows {
+		if t.rowKinds[i] == rowSeparator {
+			sb.WriteString("    <tr class=\"separator\"><td colspan=\"")
+			sb.WriteString(itoa(len(t.headers)))
+			sb.WriteString("\"></td></tr>\n")
+			continue
+		}
+		link := t.rowURL(row)
+		spans := t.spansAt(i)
+		sb.WriteString("    <tr>\n")
+		for j := range t.headers {
+			span := 1
+			if spans != nil {
+				span = spans[j]
+			}
+			if span == 0 {
+				continue
+			}
+			var cell, stored []byte
+			if j < len(row) {
+				cell, stored = row[j], row[j]
+				// Spanned cells aren't held to their first column's width.
+				if w := t.maxWidths[j]; w > 0 && span == 1 && cfg.ansi {
+					cell = t.truncateWithANSI(cell, w)
+				} else if w > 0 && span == 1 {
+					cell = []byte(TruncateToWidth(StripANSI(string(cell)), w))
+				}
+			}
+			t.writeHTMLCell(&sb, &cfg, "td", j, span, cell, t.cellColor(dataIdx, j, stored), false, link)
+		}
+		sb.WriteString("    </tr>\n")
+		dataIdx++
+	}
+	sb.WriteString("  </tbody>\n</table>")
+
+	return sb.String()
+}
+
+// writeHTMLCell writes one <th> or <td> element for column col, spanning
+// span columns. color is the table-level color of the cell, used only when
+// ANSI conversion is on. A non-empty link wraps the content in an <a>.
+func (t *Table) writeHTMLCell(sb *strings.Builder, cfg *htmlConfig, tag string, col, span int, cell []byte, color *Color, strong bool, link string) {
+	sb.WriteString("      <")
+	sb.WriteString(tag)
+	writeClassAttr(sb, cfg.colClasses[col])
+	if span > 1 {
+		sb.WriteString(` colspan="`)
+		sb.WriteString(itoa(span))
+		sb.WriteString(`"`)
+	}
+	sb.WriteString(" style=\"text-align:")
+	sb.WriteString(htmlAlign(t.aligns[col]))
+	sb.WriteString("\">")
+	if strong {
+		sb.WriteString("<strong>")
+	}
+	if link != "" {
+		sb.WriteString(`<a href="`)
+		sb.WriteString(htmlEscape(link))
+		sb.WriteString(`">`)
+	}
+
+	if cfg.ansi {
+		ansiToHTML(sb, color.Apply(string(cell)))
+	} else {
+		sb.WriteString(htmlEscape(StripANSI(string(cell))))
+	}
+
+	if link != "" {
+		sb.WriteString("</a>")
+	}
+	if strong {
+		sb.WriteString("</strong>")
+	}
+	sb.WriteString("</")
+	sb.WriteString(tag)
+	sb.WriteString(">\n")
+}
+
+// SetRowLink makes every data row of the HTML export a link, so readers of a
+// report can click through to the dashboard or ticket behind each row. fn
+// receives the row's cell values, ANSI sequences stripped, and returns its
+// URL; the content of each cell in the row is wrapped in an <a> pointing
+// there. Rows for which fn returns "" are left unlinked, and so are URLs with
+// a scheme other than http, https, mailto or ftp, so cell data can't smuggle
+// in a javascript: link. Pass nil to remove the links.
+//
+// Example:
+//
+//	t.SetRowLink(func(row []string) string {
+//	    return "https://tracker.example.com/issue/" + url.PathEscape(row[0])
+//	})
+func (t *Table) SetRowLink(fn func(row []string) string) *Table {
+	t.rowLink = fn
+	return t
+}
+
+// rowURL returns the SetRowLink URL of a data row, or "" for none.
+func (t *Table) rowURL(row [][]byte) string {
+	if t.rowLink == nil {
+		return ""
+	}
+	values := make([]string, len(t.headers))
+	for j := range values {
+		if j < len(row) {
+			values[j] = StripANSI(string(row[j]))
+		}
+	}
+	link := t.rowLink(values)
+	if !safeLinkURL(link) {
+		return ""
+	}
+	return link
+}
+
+// safeLinkURL reports whether u is relative or uses a scheme that is safe to
+// put in an href.
+func safeLinkURL(u string) bool {
+	i := strings.IndexAny(u, ":/?#")
+	if i < 0 || u[i] != ':' {
+		return true // no scheme: a relative URL
+	}
+	switch strings.ToLower(u[:i]) {
+	case "http", "https", "mailto", "ftp":
+		return true
+	}
+	return false
+}
+
+// writeClassAttr writes ` class="..."` when class is not empty.
+func writeClassAttr(sb *strings.Builder, class string) {
+	if class == "" {
+		return
+	}
+	sb.WriteString(" class=\"")
+	sb.WriteString(htmlEscape(class))
+	sb.WriteString("\"")
+}
+
+// sgrState is the text styling in effect while converting SGR sequences.
+type sgrState struct {
+	fg, bg                    string // CSS colors, empty = default
+	bold, dim, italic         bool
+	underline, strike, hidden bool
+}
+
+// css returns the inline style for s, or "" when s is the default styling.
+func (s sgrState) css() string {
+	var parts []string
+	if s.fg != "" {
+		parts = append(parts, "color:"+s.fg)
+	}
+	if s.bg != "" {
+		parts = append(parts, "background-color:"+s.bg)
+	}
+	if s.bold {
+		parts = append(parts, "font-weight:bold")
+	}
+	if s.dim {
+		parts = append(parts, "opacity:0.6")
+	}
+	if s.italic {
+		parts = append(parts, "font-style:italic")
+	}
+	switch {
+	case s.underline && s.strike:
+		parts = append(parts, "text-decoration:underline line-through")
+	case s.underline:
+		parts = append(parts, "text-decoration:underline")
+	case s.strike:
+		parts = append(parts, "text-decoration:line-through")
+	}
+	if s.hidden {
+		parts = append(parts, "visibility:hidden")
+	}
+	return strings.Join(parts, ";")
+}
+
+// ansiToHTML writes s to sb HTML-escaped, with SGR sequences turned into
+// <span style="..."> elements. Other escape sequences are dropped. Spans are
+// opened lazily, so runs of codes with no text between them produce one span.
+func ansiToHTML(sb *strings.Builder, s string) {
+	var state sgrState
+	openCSS := "" // style of the open span, "" = none open
+	text := func(txt string) {
+		if txt == "" {
+			return
+		}
+		if css := state.css(); css != openCSS {
+			if openCSS != "" {
+				sb.WriteString("</span>")
+			}
+			if css != "" {
+				sb.WriteString(`<span style="`)
+				sb.WriteString(css)
+				sb.WriteString(`">`)
+			}
+			openCSS = css
+		}
+		sb.WriteString(htmlEscape(txt))
+	}
+
+	for len(s) > 0 {
+		i := strings.IndexByte(s, '\033')
+		if i < 0 {
+			text(s)
+			break
+		}
+		text(s[:i])
+		s = s[i:]
+
+		n, kind := scanEscape(s)
+		if kind == escSGR {
+			state.apply(s[2 : n-1])
+		}
+		s = s[n:]
+	}
+	if openCSS != "" {
+		sb.WriteString("</span>")
+	}
+}
+
+// apply updates s with the parameters of one SGR sequence ("1;31" from
+// "ESC[1;31m").
+func (s *sgrState) apply(params string) {
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		code, err := strconv.Atoi(codes[i])
+		if err != nil && codes[i] != "" {
+			continue
+		}
+		switch {
+		case code == 0:
+			*s = sgrState{}
+		case code == 1:
+			s.bold = true
+		case code == 2:
+			s.dim = true
+		case code == 3:
+			s.italic = true
+		case code == 4:
+			s.underline = true
+		case code == 8:
+			s.hidden = true
+		case code == 9:
+			s.strike = true
+		case code == 22:
+			s.bold, s.dim = false, false
+		case code == 23:
+			s.italic = false
+		case code == 24:
+			s.underline = false
+		case code == 28:
+			s.hidden = false
+		case code == 29:
+			s.strike = false
+		case code >= 30 && code <= 37:
+			s.fg = ansiPalette(code - 30)
+		case code >= 90 && code <= 97:
+			s.fg = ansiPalette(code - 90 + 8)
+		case code == 39:
+			s.fg = ""
+		case code >= 40 && code <= 47:
+			s.bg = ansiPalette(code - 40)
+		case code >= 100 && code <= 107:
+			s.bg = ansiPalette(code - 100 + 8)
+		case code == 49:
+			s.bg = ""
+		case code == 38 || code == 48:
+			color, used := extendedColor(codes[i+1:])
+			i += used
+			if code == 38 {
+				s.fg = color
+			} else {
+				s.bg = color
+			}
+		}
+	}
+}
+
+// extendedColor parses the arguments following a 38 or 48 SGR code: "5;n"
+// for the 256-color palette or "2;r;g;b" for true color. It returns the CSS
+// color and how many arguments were consumed.
+func extendedColor(args []string) (color string, used int) {
+	num := func(i int) int {
+		if i >= len(args) {
+			return 0
+		}
+		n, _ := strconv.Atoi(args[i])
+		return min(max(n, 0), 255)
+	}
+	if len(args) == 0 {
+		return "", 0
+	}
+	switch args[0] {
+	case "5":
+		return ansiPalette(num(1)), min(2, len(args))
+	case "2":
+		return fmt.Sprintf("#%02x%02x%02x", num(1), num(2), num(3)), min(4, len(args))
+	}
+	return "", 1
+}
+
+// ansiBasic is the 16-color palette, using xterm's default values.
+var ansiBasic = [16]string{
+	"#000000", "#cd0000", "#00cd00", "#cdcd00", "#0000ee", "#cd00cd", "#00cdcd", "#e5e5e5",
+	"#7f7f7f", "#ff0000", "#00ff00", "#ffff00", "#5c5cff", "#ff00ff", "#00ffff", "#ffffff",
+}
+
+// ansiPalette returns the CSS color of entry n of the xterm 256-color palette.
+func ansiPalette(n int) string {
+	switch {
+	case n < 16:
+		return ansiBasic[n]
+	case n < 232:
+		n -= 16
+		level := func(v int) int {
+			if v == 0 {
+				return 0
+			}
+			return 55 + v*40
+		}
+		return fmt.Sprintf("#%02x%02x%02x", level(n/36), level(n/6%6), level(n%6))
+	default:
+		g := 8 + (n-232)*10
+		return fmt.Sprintf("#%02x%02x%02x", g, g, g)
+	}
+}
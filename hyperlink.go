@@ -0,0 +1,162 @@
+// hyperlink.go
+
+package tables
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// EnableHyperlinks controls whether auto-linked cells are wrapped in OSC 8
+// hyperlinks. It is initialized from the environment: true when stdout is a
+// terminal known to support OSC 8 (iTerm2, WezTerm, kitty, VS Code, Windows
+// Terminal, GNOME Terminal and other VTE-based terminals, Konsole, foot,
+// Alacritty, Ghostty). Set it explicitly to override the detection, e.g. when
+// rendering into a pager that passes the sequences through.
+var EnableHyperlinks = hyperlinksSupported()
+
+// linkSchemes are the URL prefixes recognized by auto-linking.
+var linkSchemes = []string{"https://", "http://", "ftp://", "file://", "mailto:"}
+
+// SetAutoLink turns automatic hyperlinking on or off for a data column
+// (0-indexed). When on, URLs and file paths found in the column's cells are
+// wrapped in OSC 8 hyperlinks at render time, so they are clickable in
+// terminals that support it. The visible text and column widths are
+// unchanged. When EnableHyperlinks is false the cells render as plain text.
+//
+// Recognized are http, https, ftp, file and mailto URLs, and paths that begin
+// with "/", "./", "../" or "~/". Relative paths are resolved against the
+// working directory at render time.
+func (t *Table) SetAutoLink(col int, on bool) *Table {
+	if !t.checkColumn(col) {
+		return t
+	}
+
+	if t.autoLink == nil {
+		t.autoLink = make(map[int]bool)
+	}
+	t.autoLink[col] = on
+	return t
+}
+
+// Hyperlink returns text wrapped in an OSC 8 hyperlink to url. The escape
+// sequences have zero display width, so the result can be added to a table
+// like any other string. On terminals without OSC 8 support only text is
+// shown. The default EscapeSanitize policy can't tell such a link from one
+// forged by untrusted data and shows only its text; tables of trusted content
+// keep it with SetEscapePolicy(EscapePassthrough).
+func Hyperlink(url, text string) string {
+	return "\033]8;;" + url + "\033\\" + text + "\033]8;;\033\\"
+}
+
+// linkify wraps every URL or path token in cell in an OSC 8 hyperlink. Tokens
+// are separated by white space; escape sequences are copied through and
+// never linked.
+func linkify(cell []byte) []byte {
+	var out []byte
+	for i := 0; i < len(cell); {
+		switch c := cell[i]; {
+		case c == '\033':
+			n, _ := scanEscape(cell[i:])
+			out = append(out, cell[i:i+n]...)
+			i += n
+			continue
+		case c == ' ' || c == '\t':
+			out = append(out, c)
+			i++
+			continue
+		}
+
+		end := i
+		for end < len(cell) && cell[end] != ' ' && cell[end] != '\t' && cell[end] != '\033' {
+			end++
+		}
+		tok := string(cell[i:end])
+		link, n := linkTarget(tok)
+		if link == "" {
+			out = append(out, tok...)
+		} else {
+			out = append(out, Hyperlink(link, tok[:n])...)
+			out = append(out, tok[n:]...)
+		}
+		i = end
+	}
+	return out
+}
+
+// linkTarget returns the URL tok links to, and how many leading bytes of tok
+// form the link text. Trailing punctuation that usually ends a sentence is
+// left outside the link. link is empty when tok is neither a URL nor a path.
+func linkTarget(tok string) (link string, n int) {
+	n = len(strings.TrimRight(tok, ".,;:!?)]}'\""))
+	text := tok[:n]
+
+	for _, scheme := range linkSchemes {
+		if len(text) > len(scheme) && strings.HasPrefix(strings.ToLower(text), scheme) {
+			return text, n
+		}
+	}
+
+	var path string
+	switch {
+	case strings.HasPrefix(text, "~/"):
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", 0
+		}
+		path = filepath.Join(home, text[2:])
+	case strings.HasPrefix(text, "/") && len(text) > 1 && text[1] != '/':
+		path = text
+	case strings.HasPrefix(text, "./"), strings.HasPrefix(text, "../"):
+		abs, err := filepath.Abs(text)
+		if err != nil {
+			return "", 0
+		}
+		path = abs
+	default:
+		return "", 0
+	}
+
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+	if !strings.HasPrefix(u.Path, "/") {
+		u.Path = "/" + u.Path // Windows drive paths
+	}
+	return u.String(), n
+}
+
+// hyperlinksSupported reports whether stdout is a terminal that understands
+// OSC 8 hyperlinks, judging by the variables terminals set in their
+// environment.
+func hyperlinksSupported() bool {
+	if !isTerminal(os.Stdout) {
+		return false
+	}
+
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "ghostty", "Hyper":
+		return true
+	}
+	if v, err := strconv.Atoi(os.Getenv("VTE_VERSION")); err == nil && v >= 5000 {
+		return true
+	}
+	if os.Getenv("WT_SESSION") != "" || os.Getenv("KONSOLE_VERSION") != "" {
+		return true
+	}
+
+	term := os.Getenv("TERM")
+	for _, name := range []string{"kitty", "alacritty", "foot", "wezterm", "ghostty"} {
+		if strings.Contains(term, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// autoLinked reports whether col has auto-linking on and hyperlinks are
+// enabled.
+func (t *Table) autoLinked(col int) bool {
+	return EnableHyperlinks && col >= 0 && t.autoLink[col]
+}
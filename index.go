@@ -0,0 +1,77 @@
+// index.go
+
+package tables
+
+import (
+	"strconv"
+)
+
+// SetAutoIndex turns on or off a leading "#" column numbering the data rows
+// from 1. The numbers are filled in when the table is rendered, so they
+// follow the rows as shown: after sorting, the first row is still 1.
+// Separators, section banners and group headers aren't numbered. The column is not part of the table's data;
+// Rows, the exporters and column indices everywhere else ignore it.
+//
+// Example:
+//
+//	t.SetAutoIndex(true).SortByColumn(1, false)
+//
+//	│ # │ Name  │ Score │
+//	├───┼───────┼───────┤
+//	│ 1 │ Alice │    95 │
+//	│ 2 │ Bob   │    87 │
+func (t *Table) SetAutoIndex(on bool) *Table {
+	t.autoIndex = on
+	return t
+}
+
+// SetIndexHeader sets the header of the SetAutoIndex column (default "#").
+func (t *Table) SetIndexHeader(header string) *Table {
+	t.indexHeader = []byte(header)
+	return t
+}
+
+// SetIndexStart sets the number of the first data row in the SetAutoIndex
+// column (default 1), e.g. 0 for zero-based numbering or the offset of a
+// page.
+func (t *Table) SetIndexStart(n int) *Table {
+	t.indexStart = n
+	return t
+}
+
+// indexed returns the table to render: t itself, or with SetAutoIndex a copy
+// with the index column in front.
+func (t *Table) indexed() *Table {
+	if !t.autoIndex {
+		return t
+	}
+
+	from := make([]int, len(t.headers)+1)
+	from[0] = -1
+	for i := range t.headers {
+		from[i+1] = i
+	}
+	cp := t.remap(from)
+	cp.autoIndex = false
+	cp.headers[0] = t.indexHeader
+	cp.aligns[0] = AlignRight
+
+	n := t.indexStart
+	for pos, kind := range cp.rowKinds {
+		if kind == rowData && !cp.isAux(pos) {
+			cp.rows[pos][0] = strconv.AppendInt(nil, int64(n), 10)
+			n++
+		}
+	}
+	if cp.locked != nil {
+		// Locked widths don't know the column; size it for the numbers now.
+		cp.locked[0] = max(cp.cellWidth(cp.indexHeader, false), len(strconv.Itoa(n-1)), len(strconv.Itoa(t.indexStart)))
+	}
+	return cp
+}
+
+// view returns the table as rendered: filtered, with the SetAutoIndex
+// column, if on, and fitted to the terminal width when fitting to one.
+func (t *Table) view() *Table {
+	return t.shown().fitted()
+}
@@ -0,0 +1,137 @@
+// inspect.go
+
+package tables
+
+// NumRows returns the number of data rows. Separator rows are not counted.
+func (t *Table) NumRows() int {
+	return t.dataRowsFrom(0)
+}
+
+// NumColumns returns the number of columns, which is the number of headers.
+func (t *Table) NumColumns() int {
+	return len(t.headers)
+}
+
+// Headers returns a copy of the column headers.
+func (t *Table) Headers() []string {
+	headers := make([]string, len(t.headers))
+	for i, h := range t.headers {
+		headers[i] = string(h)
+	}
+	return headers
+}
+
+// Rows returns a copy of the data rows, one []byte per cell, exactly as
+// stored (ANSI sequences included). Separator rows are skipped, so index i
+// matches the row numbering used by SetRowColor and SetCellColor. Modifying
+// the result does not affect the table.
+func (t *Table) Rows() [][][]byte {
+	t = t.warm()
+	rows := make([][][]byte, 0, len(t.rows))
+	for i, row := range t.rows {
+		if t.rowKinds[i] == rowSeparator {
+			continue
+		}
+		cp := make([][]byte, len(row))
+		for j, cell := range row {
+			cp[j] = append([]byte(nil), cell...)
+		}
+		rows = append(rows, cp)
+	}
+	return rows
+}
+
+// Column returns a copy of the values in data column col (0-indexed), one per
+// data row, top to bottom. Separator rows are skipped. It returns nil if col
+// is out of range.
+//
+// Example:
+//
+//	var total float64
+//	for _, v := range t.Column(2) {
+//	    f, _ := strconv.ParseFloat(string(v), 64)
+//	    total += f
+//	}
+func (t *Table) Column(col int) [][]byte {
+	t = t.warm()
+	if col < 0 || col >= len(t.headers) {
+		return nil
+	}
+
+	values := make([][]byte, 0, len(t.rows))
+	for i, row := range t.rows {
+		if t.rowKinds[i] == rowSeparator {
+			continue
+		}
+		values = append(values, append([]byte(nil), row[col]...))
+	}
+	return values
+}
+
+// ColumnByName returns the values of the first column whose header is name,
+// like Column. It returns nil if no header matches.
+func (t *Table) ColumnByName(name string) [][]byte {
+	return t.Column(t.headerIndex(name))
+}
+
+// headerIndex returns the index of the first header equal to name, or -1.
+func (t *Table) headerIndex(name string) int {
+	for i, h := range t.headers {
+		if string(h) == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// ColumnWidths returns the content width of each column as it would be
+// rendered now: measured from the data, capped by SetMaxWidth, or frozen by
+// LockWidths. Borders and the one-space cell padding are not included.
+func (t *Table) ColumnWidths() []int {
+	t = t.warm()
+	widths := t.columnWidths()
+	return append([]int(nil), widths...)
+}
+
+// ForEachRow calls fn for every data row in order, skipping separators; idx
+// is the data row index used by SetRowColor and SetCellColor. Iteration stops
+// early when fn returns false.
+//
+// cells is a copy, so fn can't change the table through it, but the copy's
+// storage is reused between calls: cells and the slices in it are only valid
+// until fn returns. Copy anything you need to keep.
+//
+// Example:
+//
+//	t.ForEachRow(func(idx int, cells [][]byte) bool {
+//	    fmt.Fprintf(w, "%d: %s\n", idx, bytes.Join(cells, []byte(", ")))
+//	    return true
+//	})
+func (t *Table) ForEachRow(fn func(idx int, cells [][]byte) bool) {
+	t = t.warm()
+	cells := make([][]byte, len(t.headers))
+	var buf []byte
+
+	idx := 0
+	for i, row := range t.rows {
+		if t.rowKinds[i] == rowSeparator {
+			continue
+		}
+
+		buf = buf[:0]
+		for _, cell := range row {
+			buf = append(buf, cell...)
+		}
+		cells = cells[:len(row)]
+		off := 0
+		for j, cell := range row {
+			cells[j] = buf[off : off+len(cell) : off+len(cell)]
+			off += len(cell)
+		}
+
+		if !fn(idx, cells) {
+			return
+		}
+		idx++
+	}
+}
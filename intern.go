@@ -0,0 +1,111 @@
+// intern.go
+
+package tables
+
+const (
+	// internMaxLen is the longest cell value SetInterning stores: status
+	// strings and labels are short, and long values rarely repeat.
+	internMaxLen = 64
+
+	// internSample is the number of cells of a column SetInterning looks at
+	// before deciding whether the column repeats enough to be worth it.
+	internSample = 256
+)
+
+// internPool holds one copy of each interned cell value.
+type internPool struct {
+	values map[string][]byte
+	size   int // Bytes of values held
+	quota  int // Most bytes of values to hold
+
+	seen  []int // Cells looked up, by column
+	added []int // Values added to the pool, by column
+}
+
+// skip reports whether column col has shown too few repeats to intern: more
+// than half of its first internSample values were new.
+func (p *internPool) skip(col int) bool {
+	return col < len(p.seen) && p.seen[col] >= internSample && 2*p.added[col] > p.seen[col]
+}
+
+// SetInterning makes the table store each distinct short cell value once and
+// share it between every cell holding it, so tables with many rows of
+// repetitive data, such as status strings, enum labels or host names, take a
+// fraction of the memory. quota caps the bytes of distinct values kept: once
+// it is reached, values already seen are still shared but new ones are
+// stored per cell as usual. Values over 64 bytes are never interned, and
+// neither are columns that don't repeat, such as IDs or timestamps: a column
+// stops being interned when more than half of its first 256 values are
+// distinct, so they don't use up the quota.
+//
+// Interning applies to cells added or set after the call, after
+// SetNormalizeNFC normalizes them. It costs a map lookup per cell, which a
+// batch of AddRowsBytes makes up for by skipping the copy of values already
+// held. quota <= 0 turns interning off and releases the pool; cells already
+// sharing a value keep it.
+//
+// Example:
+//
+//	t := tables.NewFromStrings("Host", "Status", "Latency").
+//	    SetInterning(1 << 20) // up to 1 MiB of distinct values
+//	for _, r := range results {
+//	    t.AddRow(r.Host, r.Status, r.Latency)
+//	}
+func (t *Table) SetInterning(quota int) *Table {
+	if quota <= 0 {
+		t.interns = nil
+		return t
+	}
+	if t.interns == nil {
+		t.interns = &internPool{values: make(map[string][]byte)}
+	}
+	t.interns.quota = quota
+	return t
+}
+
+// intern returns the shared copy of cell, a value of column col, adding one
+// if the pool has room, and reports whether it did.
+func (t *Table) intern(col int, cell []byte) ([]byte, bool) {
+	p := t.interns
+	if p == nil || len(cell) == 0 || len(cell) > internMaxLen || p.skip(col) {
+		return nil, false
+	}
+	v, ok := p.values[string(cell)]
+	if ok && &v[0] == &cell[0] {
+		return v, true // shared already, don't count it again
+	}
+	p.seen = growTo(p.seen, col+1)
+	p.added = growTo(p.added, col+1)
+	p.seen[col]++
+	if ok {
+		return v, true
+	}
+	p.added[col]++
+	if p.size+len(cell) > p.quota {
+		return nil, false
+	}
+	v = make([]byte, len(cell))
+	copy(v, cell)
+	p.values[string(v)] = v
+	p.size += len(v)
+	return v, true
+}
+
+// interned returns the shared copy of cell if the pool holds one, without
+// adding it or counting the lookup.
+func (t *Table) interned(cell []byte) ([]byte, bool) {
+	if t.interns == nil || len(cell) == 0 {
+		return nil, false
+	}
+	v, ok := t.interns.values[string(cell)]
+	return v, ok
+}
+
+// internRow replaces the cells of row with their shared copies.
+func (t *Table) internRow(row [][]byte) {
+	for i, cell := range row {
+		if v, ok := t.intern(i, cell); ok {
+			row[i] = v
+		}
+	}
+}
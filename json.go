@@ -0,0 +1,115 @@
+// json.go
+
+package tables
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// NewFromJSON builds a table from a JSON array of flat objects, such as a
+// typical REST list response:
+//
+//	[{"id": 1, "name": "api"}, {"id": 2, "name": "db", "region": "eu"}]
+//
+// Headers are the object keys in the order they are first seen, so the output
+// is stable across runs. Objects missing a key get an empty cell. Strings are
+// shown unquoted, numbers exactly as written in the input, null as an empty
+// cell, and nested objects or arrays as compact JSON.
+func NewFromJSON(data []byte) (*Table, error) {
+	return NewFromJSONReader(bytes.NewReader(data))
+}
+
+// NewFromJSONReader is like NewFromJSON but decodes the array from r one
+// element at a time.
+func NewFromJSONReader(r io.Reader) (*Table, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	if tok, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("tables: reading JSON: %w", err)
+	} else if tok != json.Delim('[') {
+		return nil, fmt.Errorf("tables: reading JSON: expected an array of objects, got %v", tok)
+	}
+
+	var keys []string
+	seen := make(map[string]bool)
+	var records []map[string][]byte
+
+	for dec.More() {
+		rec := make(map[string][]byte)
+		err := decodeObject(dec, func(key string, cell []byte) {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+			rec[key] = cell
+		})
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	if _, err := dec.Token(); err != nil { // closing ']'
+		return nil, fmt.Errorf("tables: reading JSON: %w", err)
+	}
+
+	t := NewFromStrings(keys...)
+	row := make([][]byte, len(keys))
+	for _, rec := range records {
+		for i, k := range keys {
+			row[i] = rec[k]
+		}
+		t.AddRowBytes(row...)
+	}
+	return t, nil
+}
+
+// decodeObject reads one flat JSON object from dec and calls field with each
+// key and its cell text, in the order they are written.
+func decodeObject(dec *json.Decoder, field func(key string, cell []byte)) error {
+	if tok, err := dec.Token(); err != nil {
+		return fmt.Errorf("tables: reading JSON: %w", err)
+	} else if tok != json.Delim('{') {
+		return fmt.Errorf("tables: reading JSON: expected an object, got %v", tok)
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("tables: reading JSON: %w", err)
+		}
+		key := tok.(string) // object keys are always strings
+
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return fmt.Errorf("tables: reading JSON value for %q: %w", key, err)
+		}
+		field(key, jsonCell(raw))
+	}
+	if _, err := dec.Token(); err != nil { // closing '}'
+		return fmt.Errorf("tables: reading JSON: %w", err)
+	}
+	return nil
+}
+
+// jsonCell converts a raw JSON value to its cell text.
+func jsonCell(raw json.RawMessage) []byte {
+	switch {
+	case len(raw) == 0 || string(raw) == "null":
+		return nil
+	case raw[0] == '"':
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			return []byte(s)
+		}
+	case raw[0] == '{' || raw[0] == '[':
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, raw); err == nil {
+			return buf.Bytes()
+		}
+	}
+	return raw
+}
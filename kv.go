@@ -0,0 +1,47 @@
+// kv.go
+
+package tables
+
+// kvValueWidth is the width values wrap at in a KV table.
+const kvValueWidth = 60
+
+// KV is a two-column property table of keys and their values, as built by
+// NewKV. It is a Table, so every setting and output method applies.
+type KV struct {
+	*Table
+}
+
+// NewKV returns an empty property table with "Key" and "Value" columns, for
+// the settings, metadata and status summaries that are listed one field per
+// line. Keys are left-aligned and values wrap at 60 cells, which
+// SetMaxWidth(1, n) changes. The table is drawn with the default borders;
+// SetStyle(StyleNone) leaves them out.
+//
+// Example:
+//
+//	tables.NewKV().
+//	    Add("Name", "web-7f9c").
+//	    Add("Status", "Running").
+//	    Add("Restarts", 3).
+//	    Print()
+//
+//	┌──────────┬──────────┐
+//	│ Key      │ Value    │
+//	├──────────┼──────────┤
+//	│ Name     │ web-7f9c │
+//	│ Status   │ Running  │
+//	│ Restarts │ 3        │
+//	└──────────┴──────────┘
+func NewKV() *KV {
+	t := NewFromStrings("Key", "Value").
+		SetAlign(0, AlignLeft).
+		SetMaxWidth(1, kvValueWidth).
+		SetWrap(1, true)
+	return &KV{t}
+}
+
+// Add adds a row with key and its value, which is formatted as by AddRow.
+func (kv *KV) Add(key string, value interface{}) *KV {
+	kv.AddRow(key, value)
+	return kv
+}
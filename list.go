@@ -0,0 +1,151 @@
+// list.go
+
+package tables
+
+import (
+	"strconv"
+	"strings"
+)
+
+// nbsp is the no-break space List puts inside items, so SetWrap only breaks
+// lines between them.
+const nbsp = "\u00a0"
+
+// listLocale is how a locale joins the items of a list.
+type listLocale struct {
+	sep  string // Between items
+	two  string // Between the items of a list of two
+	last string // Before the last of three or more items
+}
+
+var (
+	listEN = listLocale{", ", " and ", ", and "}
+	listGB = listLocale{", ", " and ", " and "}
+	listJA = listLocale{"、", "、", "、"}
+)
+
+// listLocales maps the names ListLocale accepts, lowercased, to their
+// separators. A bare language stands for its most common region.
+var listLocales = map[string]listLocale{
+	"en": listEN, "en-us": listEN, "en-ca": listEN,
+	"en-gb": listGB, "en-au": listGB, "en-ie": listGB, "en-nz": listGB, "en-in": listGB,
+
+	"de": {", ", " und ", " und "}, "fr": {", ", " et ", " et "},
+	"es": {", ", " y ", " y "}, "it": {", ", " e ", " e "},
+	"pt": {", ", " e ", " e "}, "nl": {", ", " en ", " en "},
+	"sv": {", ", " och ", " och "}, "nb": {", ", " og ", " og "},
+	"da": {", ", " og ", " og "}, "fi": {", ", " ja ", " ja "},
+	"pl": {", ", " i ", " i "}, "cs": {", ", " a ", " a "},
+	"ru": {", ", " и ", " и "}, "uk": {", ", " і ", " і "},
+	"tr": {", ", " ve ", " ve "}, "el": {", ", " και ", " και "},
+
+	"ja": listJA, "zh": {"、", "和", "和"}, "ko": {", ", " 및 ", " 및 "},
+}
+
+// lookupListLocale finds the separators for a locale name such as "en-GB",
+// "de_DE" or "fr", falling back from an unknown region to the language.
+func lookupListLocale(name string) (listLocale, bool) {
+	name = strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+	if loc, ok := listLocales[name]; ok {
+		return loc, true
+	}
+	lang, _, _ := strings.Cut(name, "-")
+	loc, ok := listLocales[lang]
+	return loc, ok
+}
+
+// listConfig holds the settings applied by ListOption values.
+type listConfig struct {
+	locale listLocale
+	plain  bool // No conjunction before the last item
+	max    int  // Most items shown (0 = all)
+	width  int  // Most terminal cells taken (0 = any)
+}
+
+// ListOption configures List.
+type ListOption func(*listConfig)
+
+// ListLocale joins the items the way locale does: "a, b, and c" in "en-US",
+// "a, b and c" in "en-GB", "a, b und c" in "de", "a、b、c" in "ja". Locale
+// names are accepted as by SetDateLocale; an unknown one keeps the English
+// default.
+func ListLocale(locale string) ListOption {
+	return func(c *listConfig) {
+		if loc, ok := lookupListLocale(locale); ok {
+			c.locale = loc
+		}
+	}
+}
+
+// ListPlain leaves out the word before the last item, joining every item
+// with the locale's separator alone ("a, b, c"), as tags and labels
+// usually are.
+func ListPlain() ListOption {
+	return func(c *listConfig) { c.plain = true }
+}
+
+// ListMax shows at most n items, followed by a count of the rest such as
+// "(+3 more)".
+func ListMax(n int) ListOption {
+	return func(c *listConfig) { c.max = max(n, 0) }
+}
+
+// ListWidth shows as many items as fit in width terminal cells, with the
+// count of the rest, measured with the default width function. The first
+// item is always shown.
+func ListWidth(width int) ListOption {
+	return func(c *listConfig) { c.width = max(width, 0) }
+}
+
+// List formats items as the text of one cell, for tag and label columns:
+//
+//	tables.List([]string{"api", "db", "cache"})              // api, db, and cache
+//	tables.List(tags, tables.ListLocale("de"))               // api, db und cache
+//	tables.List(tags, tables.ListPlain(), tables.ListMax(2)) // api, db (+1 more)
+//
+// Spaces inside an item are written as no-break spaces (U+00A0), so a column
+// with SetWrap breaks its lines between items only, and an item is never
+// split across lines unless it is wider than the column on its own. Items
+// may be colored. The count of items left out by ListMax or ListWidth is in
+// English.
+func List(items []string, opts ...ListOption) string {
+	c := listConfig{locale: listEN}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	n := len(items)
+	if c.max > 0 {
+		n = min(n, c.max)
+	}
+	s := c.join(items, n)
+	for c.width > 0 && n > 1 && MeasureWidthIgnoreANSI(s) > c.width {
+		n--
+		s = c.join(items, n)
+	}
+	return s
+}
+
+// join returns the first n items joined, followed by the count of the rest.
+func (c *listConfig) join(items []string, n int) string {
+	var b strings.Builder
+	for i, item := range items[:n] {
+		switch {
+		case i == 0:
+		case c.plain || n < len(items) || i < n-1:
+			b.WriteString(c.locale.sep)
+		case n == 2:
+			b.WriteString(c.locale.two)
+		default:
+			b.WriteString(c.locale.last)
+		}
+		b.WriteString(strings.ReplaceAll(item, " ", nbsp))
+	}
+	if rest := len(items) - n; rest > 0 {
+		if n > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString("(+" + strconv.Itoa(rest) + nbsp + "more)")
+	}
+	return b.String()
+}
@@ -0,0 +1,355 @@
+// live.go
+
+package tables
+
+import (
+	"bytes"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+// Live redraws a table in place on a terminal, for dashboards and progress
+// displays that refresh many times a second. Create one with Table.Live,
+// change the table with SetCell, UpdateRow or AddRow, and call Update to show
+// the changes.
+//
+// Live tracks which rows changed since the last frame. When only cell values
+// changed and every column kept its width, Update renders just those rows, so
+// a 500-row table with a handful of changing values costs a handful of rows
+// per frame. Anything else (rows added or reordered, widths changed) renders
+// the whole table again.
+//
+// Either way, the new lines are compared with the frame on screen and only
+// the lines that differ are written, with cursor movement in between. Nothing
+// is cleared and redrawn, so updates don't flicker and stay small over slow
+// links such as SSH.
+//
+// When w is a terminal, columns are narrowed as needed to fit its width,
+// re-measured on every update. If the width changed since the last frame the
+// terminal may have rewrapped it, so the screen is cleared and the table
+// drawn again from the top; Resized reports resizes as they happen.
+//
+// Live writes cursor-movement sequences and expects w to be a terminal, with
+// the whole table fitting on screen. It is not safe for concurrent use; do
+// not write to w between updates.
+type Live struct {
+	t *Table
+	w io.Writer
+
+	// The frame on screen is front, split into lines (without newlines);
+	// back is where the next full frame is rendered before the two swap.
+	front, back bytes.Buffer
+	lines       [][]byte
+	next        [][]byte
+	cur         int // cursor line during an update; len(lines) between them
+
+	drawn     bool
+	widths    []int       // column widths of the frame on screen
+	rows      int         // len(t.rows) when it was drawn
+	pos       []liveLines // where each entry of t.rows was drawn
+	hashes    []uint64    // rowHash of each entry of t.rows when drawn
+	footer    liveLines   // where the footer was drawn
+	hasFooter bool
+
+	termCols   int           // terminal width of the frame on screen (0 = not a terminal)
+	resized    chan struct{} // see Resized
+	stopResize func()
+}
+
+// liveLines locates a rendered row within a frame.
+type liveLines struct {
+	line, n int // first line and line count
+	dataIdx int // data row index, for row colors
+}
+
+// Live returns a Live display of t that draws to w. Nothing is written until
+// the first Update.
+//
+// Example:
+//
+//	live := t.Live(os.Stdout)
+//	for range time.Tick(100 * time.Millisecond) {
+//	    t.SetCell(3, 2, readGauge())
+//	    live.Update()
+//	}
+func (t *Table) Live(w io.Writer) *Live {
+	return &Live{t: t, w: w}
+}
+
+// Update brings the display up to date with the table: the first call draws
+// it, later calls rewrite the lines that changed since the previous frame.
+func (l *Live) Update() error {
+	t := l.t
+	buf := t.bufPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer t.bufPool.Put(buf)
+
+	src := t
+	if cols, ok := l.terminalWidth(); ok {
+		if l.drawn && cols != l.termCols {
+			// The old frame may have been rewrapped to the new width, so
+			// relative cursor moves can no longer find it: start over at
+			// the top of a cleared screen.
+			buf.WriteString("\033[H\033[2J")
+			l.lines = l.lines[:0]
+			l.cur = 0
+			l.drawn = false
+		}
+		l.termCols = cols
+		// Fit a copy, so that t can be rendered elsewhere at the same time.
+		fitted := *t
+		fitted.fitWidth = cols
+		src = &fitted
+	}
+	v := src.view() // src, or a copy with the index column or without the ones that don't fit
+	widths := v.columnWidths()
+
+	full := !l.drawn || t.dirtyAll || len(t.rows) != l.rows || v != src ||
+		(t.footer != nil) != l.hasFooter || !slices.Equal(widths, l.widths) ||
+		len(t.mergeCols) > 0 || t.rowDown != nil || // a change can merge or split the rows below it
+		len(t.columnKinds) > 0 // relative times change with every frame
+	if full || !l.patch(buf, widths) {
+		l.redraw(buf, v, widths)
+	}
+	t.dirty = nil
+	t.dirtyAll = false
+
+	_, err := buf.WriteTo(l.w)
+	return err
+}
+
+// Refresh redraws the whole table on the next Update. Use it after changes
+// Live can't track, such as new colors, alignment or style.
+func (l *Live) Refresh() *Live {
+	l.t.dirtyAll = true
+	return l
+}
+
+// Resized returns a channel that receives a value when the terminal w writes
+// to is resized (SIGWINCH on Unix; the console size is polled on Windows), so
+// a program that updates rarely can re-layout right away instead of at its
+// next tick:
+//
+//	for {
+//	    select {
+//	    case <-tick.C:
+//	    case <-live.Resized():
+//	    }
+//	    live.Update()
+//	}
+//
+// It returns nil, which blocks forever in a select, when w is not a terminal.
+// Call Stop when done with the Live to release the notification.
+func (l *Live) Resized() <-chan struct{} {
+	if l.resized == nil {
+		f, ok := l.w.(fdWriter)
+		if !ok || !isTerminalFd(f.Fd()) {
+			return nil
+		}
+		l.resized = make(chan struct{}, 1)
+		l.stopResize = notifyResize(f.Fd(), l.resized)
+	}
+	return l.resized
+}
+
+// Stop ends the resize notifications started by Resized. The table on screen
+// is left as it is.
+func (l *Live) Stop() {
+	if l.stopResize != nil {
+		l.stopResize()
+		l.stopResize = nil
+		l.resized = nil
+	}
+}
+
+// terminalWidth returns the width of the terminal w writes to.
+func (l *Live) terminalWidth() (cols int, ok bool) {
+	f, ok := l.w.(fdWriter)
+	if !ok {
+		return 0, false
+	}
+	cols, _, ok = terminalSize(f.Fd())
+	return cols, ok
+}
+
+// redraw renders t, the table or its fitted copy, as a complete frame into
+// the back buffer, writes the lines that differ from the frame on screen,
+// and swaps the buffers.
+func (l *Live) redraw(buf *bytes.Buffer, t *Table, widths []int) {
+	l.back.Reset()
+	b := &liveBackend{textBackend: textBackend{t: t, buf: &l.back}, pos: l.pos[:0]}
+	truncated := t.Render(b) != nil
+	l.next = splitLines(l.next[:0], l.back.Bytes())
+
+	for i, line := range l.next {
+		if i < len(l.lines) && bytes.Equal(line, l.lines[i]) {
+			continue
+		}
+		l.writeLine(buf, i, line)
+	}
+	// Clear whatever is left of a taller previous frame.
+	l.moveTo(buf, len(l.next))
+	if len(l.next) < len(l.lines) {
+		buf.WriteString("\033[J")
+	}
+
+	l.front, l.back = l.back, l.front
+	l.lines, l.next = l.next, l.lines
+	l.cur = len(l.lines)
+
+	l.drawn = true
+	l.widths = widths
+	l.rows = len(t.rows)
+	if truncated {
+		// Cut short by SetMaxOutputBytes: the positions are incomplete, so
+		// the next update has to render everything again.
+		l.rows = -1
+	}
+	l.pos = b.pos
+	l.hashes = l.hashes[:0]
+	for pos := range l.t.rows {
+		l.hashes = append(l.hashes, l.t.rowHash(pos))
+	}
+	l.footer = b.footer
+	l.hasFooter = t.footer != nil
+}
+
+// patch renders only the rows marked dirty, plus the footer, which often
+// holds totals of the changed values, and writes the lines that differ from
+// the frame on screen. It reports false, writing nothing, when a row no
+// longer takes the same number of lines and the frame must be redrawn.
+func (l *Live) patch(buf *bytes.Buffer, widths []int) bool {
+	t := l.t
+
+	positions := make([]int, 0, len(t.dirty))
+	for pos := range t.dirty {
+		// Rows set to what they already held, as polling loops often do,
+		// need no rendering.
+		if h := t.rowHash(pos); h != l.hashes[pos] {
+			l.hashes[pos] = h
+			positions = append(positions, pos)
+		}
+	}
+	sort.Ints(positions)
+
+	scratch := t.bufPool.Get().(*bytes.Buffer)
+	scratch.Reset()
+	defer t.bufPool.Put(scratch)
+
+	// Render everything first so a height change is found before any output.
+	type patched struct {
+		at    liveLines
+		start int // index of the row's first line in l.next
+	}
+	var rows []patched
+	l.next = l.next[:0]
+	render := func(cells [][]byte, at liveLines, ascii bool, spans []int) bool {
+		mark := scratch.Len()
+		t.renderRow(scratch, cells, widths, at.dataIdx, ascii, spans)
+		start := len(l.next)
+		l.next = splitLines(l.next, scratch.Bytes()[mark:])
+		rows = append(rows, patched{at, start})
+		return len(l.next)-start == at.n
+	}
+	for _, pos := range positions {
+		if !render(t.rows[pos], l.pos[pos], t.rowASCII[pos], t.spansAt(pos)) {
+			return false
+		}
+	}
+	if t.footer != nil && !render(t.footer, l.footer, rowIsASCII(t.footer), nil) {
+		return false
+	}
+
+	for _, r := range rows {
+		for k, line := range l.next[r.start : r.start+r.at.n] {
+			i := r.at.line + k
+			if bytes.Equal(line, l.lines[i]) {
+				continue
+			}
+			l.writeLine(buf, i, line)
+			// Keep the on-screen copy current; it can't point into scratch,
+			// which goes back to the pool.
+			l.lines[i] = append([]byte(nil), line...)
+		}
+	}
+	l.moveTo(buf, len(l.lines))
+	return true
+}
+
+// writeLine writes line over screen line i, clearing anything left of the
+// old, longer line, and leaves the cursor at the start of the next line.
+func (l *Live) writeLine(buf *bytes.Buffer, i int, line []byte) {
+	l.moveTo(buf, i)
+	buf.Write(line)
+	buf.WriteString("\033[K\n")
+	l.cur = i + 1
+}
+
+// moveTo moves the cursor to the start of line i of the frame.
+func (l *Live) moveTo(buf *bytes.Buffer, i int) {
+	if i == l.cur {
+		return
+	}
+	buf.WriteString("\r\033[")
+	if i < l.cur {
+		buf.WriteString(strconv.Itoa(l.cur - i))
+		buf.WriteByte('A')
+	} else {
+		buf.WriteString(strconv.Itoa(i - l.cur))
+		buf.WriteByte('B')
+	}
+	l.cur = i
+}
+
+// splitLines appends the lines of b to dst, without their newlines.
+func splitLines(dst [][]byte, b []byte) [][]byte {
+	for len(b) > 0 {
+		i := bytes.IndexByte(b, '\n')
+		if i < 0 {
+			return append(dst, b)
+		}
+		dst = append(dst, b[:i:i])
+		b = b[i+1:]
+	}
+	return dst
+}
+
+// liveBackend is the text renderer plus bookkeeping of where each row ends
+// up, so later updates can find it again.
+type liveBackend struct {
+	textBackend
+	line   int // lines written so far
+	pos    []liveLines
+	footer liveLines
+}
+
+func (b *liveBackend) WriteHeader(cells [][]byte) error {
+	mark := b.buf.Len()
+	err := b.textBackend.WriteHeader(cells)
+	b.line += bytes.Count(b.buf.Bytes()[mark:], []byte("\n"))
+	return err
+}
+
+func (b *liveBackend) WriteRow(row Row) error {
+	mark := b.buf.Len()
+	err := b.textBackend.WriteRow(row)
+	n := bytes.Count(b.buf.Bytes()[mark:], []byte("\n"))
+	// A rule or repeated header above the row isn't part of it.
+	b.pos = append(b.pos, liveLines{line: b.line + b.lead, n: n - b.lead, dataIdx: row.Index})
+	b.line += n
+	return err
+}
+
+func (b *liveBackend) End(footer [][]byte) error {
+	mark := b.buf.Len()
+	err := b.textBackend.End(footer)
+	if footer != nil {
+		// The footer's lines sit between two border lines.
+		n := bytes.Count(b.buf.Bytes()[mark:], []byte("\n"))
+		b.footer = liveLines{line: b.line + 1, n: n - 2, dataIdx: -2}
+	}
+	b.line += bytes.Count(b.buf.Bytes()[mark:], []byte("\n"))
+	return err
+}
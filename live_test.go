@@ -0,0 +1,38 @@
+// live_test.go
+
+package tables
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestLiveHeaderRepeat checks that Live draws a repeated header like String
+// does, rather than whatever row was handed to the backend before it.
+func TestLiveHeaderRepeat(t *testing.T) {
+	tbl := New([]byte("Host"), []byte("CPU"))
+	tbl.AddRow("h1", 1).AddRow("h2", 2).AddRow("h3", 3).SetHeaderRepeat(2)
+
+	var out bytes.Buffer
+	if err := tbl.Live(&out).Update(); err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Split(StripANSI(out.String()), "\n")
+	want := strings.Split(tbl.String(), "\n")
+	header := want[1]
+	if n, m := count(got, header), count(want, header); n != m {
+		t.Errorf("Live drew the header %d times, String %d times:\n%s", n, m, strings.Join(got, "\n"))
+	}
+}
+
+// count returns how many of lines equal line.
+func count(lines []string, line string) int {
+	n := 0
+	for _, l := range lines {
+		if l == line {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,73 @@
+// maps.go
+
+package tables
+
+import "sort"
+
+// NewFromMaps builds a table from a slice of maps, as produced by decoding
+// JSON or YAML into map[string]interface{}. The columns argument selects which
+// keys become columns and in what order; keys not listed are ignored and
+// listed keys missing from a map become empty cells.
+//
+// When no columns are given, every key found in data is used, sorted
+// alphabetically since maps carry no order of their own.
+//
+// Example:
+//
+//	var items []map[string]interface{}
+//	json.Unmarshal(body, &items)
+//	tables.NewFromMaps(items, "id", "name", "status").Print()
+func NewFromMaps(data []map[string]interface{}, columns ...string) *Table {
+	if len(columns) == 0 {
+		seen := make(map[string]bool)
+		for _, m := range data {
+			for k := range m {
+				if !seen[k] {
+					seen[k] = true
+					columns = append(columns, k)
+				}
+			}
+		}
+		sort.Strings(columns)
+	}
+
+	t := NewFromStrings(columns...)
+	values := make([]any, len(columns))
+	for _, m := range data {
+		for i, col := range columns {
+			v, ok := m[col]
+			if !ok || v == nil {
+				v = ""
+			}
+			values[i] = v
+		}
+		t.AddRow(values...)
+	}
+	return t
+}
+
+// AddRowMap adds a row whose values are placed by header name rather than by
+// position, so the call keeps working when columns are added or reordered.
+// Keys that match no header are ignored, and columns without a key (or with a
+// nil value) are left empty. Values are converted like AddRow's. If several
+// headers share a name, each of them receives the value.
+//
+// Example:
+//
+//	t := tables.NewFromStrings("Name", "Role", "Team")
+//	t.AddRowMap(map[string]interface{}{"Team": "infra", "Name": "Alice"})
+func (t *Table) AddRowMap(values map[string]interface{}) *Table {
+	if len(values) == 0 {
+		return t
+	}
+
+	row := make([]any, len(t.headers))
+	for i, h := range t.headers {
+		v, ok := values[string(h)]
+		if !ok || v == nil {
+			v = ""
+		}
+		row[i] = v
+	}
+	return t.AddRow(row...)
+}
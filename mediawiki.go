@@ -0,0 +1,102 @@
+// mediawiki.go
+
+package tables
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+// MediaWiki returns the table as MediaWiki markup, ready for a wiki bot to
+// publish:
+//
+//	{| class="wikitable"
+//	! Name !! Score
+//	|-
+//	| Alice || style="text-align:right" | 95
+//	|}
+//
+// Headers become ! cells. Right- and center-aligned columns carry an inline
+// text-align style. The footer, if set, is a last row of ! cells so it shows
+// in bold like the header. ANSI sequences are stripped, column max widths
+// apply, pipes are written as &#124; and line breaks as <br />, so cell text
+// can't break the table. Separator rows are omitted.
+func (t *Table) MediaWiki() string {
+	t = t.warm()
+	if len(t.headers) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	t.writeMediaWiki(&sb)
+	return sb.String()
+}
+
+// WriteMediaWikiTo writes the MediaWiki form of the table to w. See MediaWiki.
+func (t *Table) WriteMediaWikiTo(w io.Writer) (int64, error) {
+	t = t.warm()
+	if len(t.headers) == 0 {
+		return 0, nil
+	}
+
+	buf := t.bufPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer t.bufPool.Put(buf)
+
+	t.writeMediaWiki(buf)
+	return buf.WriteTo(w)
+}
+
+// writeMediaWiki renders the MediaWiki table into sb.
+func (t *Table) writeMediaWiki(sb io.StringWriter) {
+	sb.WriteString("{| class=\"wikitable\"\n")
+	t.wikiLine(sb, "!", t.headers, false)
+	for i, row := range t.rows {
+		if t.rowKinds[i] == rowSeparator {
+			continue
+		}
+		sb.WriteString("|-\n")
+		t.wikiLine(sb, "|", row, true)
+	}
+	if t.footer != nil {
+		sb.WriteString("|-\n")
+		t.wikiLine(sb, "!", t.footer, false)
+	}
+	sb.WriteString("|}\n")
+}
+
+// wikiLine writes one row on a single line, its cells separated by a doubled
+// marker ("||" or "!!"). Data rows are truncated to the column max widths.
+func (t *Table) wikiLine(sb io.StringWriter, marker string, row [][]byte, data bool) {
+	sb.WriteString(marker)
+	for j := range t.headers {
+		if j > 0 {
+			sb.WriteString(" ")
+			sb.WriteString(marker)
+			sb.WriteString(marker)
+		}
+		if t.aligns[j] != AlignLeft {
+			sb.WriteString(` style="text-align:`)
+			sb.WriteString(htmlAlign(t.aligns[j]))
+			sb.WriteString(`" |`)
+		}
+
+		var cell string
+		if j < len(row) {
+			cell = StripANSI(string(row[j]))
+			if data && t.maxWidths[j] > 0 {
+				cell = TruncateToWidth(cell, t.maxWidths[j])
+			}
+		}
+		if cell != "" {
+			sb.WriteString(" ")
+			sb.WriteString(wikiEscaper.Replace(cell))
+		}
+	}
+	sb.WriteString("\n")
+}
+
+// wikiEscaper escapes the characters that would end or split a cell: pipes,
+// the "!!" header separator, and line breaks, which become <br />.
+var wikiEscaper = strings.NewReplacer("|", "&#124;", "!!", "!&#33;", "\r\n", "<br />", "\n", "<br />", "\r", "<br />")
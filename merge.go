@@ -0,0 +1,181 @@
+// merge.go
+
+package tables
+
+// SetMergeRepeated turns merging of repeated values in column col on or off.
+// A value equal to the one in the data row above is left blank, so runs of
+// the same value read as one cell, as in a grouped report. Separator rows
+// inside a run don't cut through the merged cell: the column stays open
+// across them.
+//
+// Merging is hierarchical from left to right: a value only merges if every
+// merged column to its left merges too, so a repeated "Widget" under a new
+// region starts again. Rows added with AddSpannedRow break runs. Only the
+// text output is affected; the stored data and the exports keep every value.
+//
+// Example:
+//
+//	t.SetMergeRepeated(0, true).SetMergeRepeated(1, true)
+//
+//	│ EU     │ Widget  │    12 │
+//	│        │         │     8 │
+//	│        │ Gadget  │     3 │
+//	├────────┼─────────┼───────┤
+//	│ US     │ Widget  │    30 │
+func (t *Table) SetMergeRepeated(col int, on bool) *Table {
+	if !t.checkColumn(col) {
+		return t
+	}
+	if !on {
+		delete(t.mergeCols, col)
+		return t
+	}
+	if t.mergeCols == nil {
+		t.mergeCols = make(map[int]bool)
+	}
+	t.mergeCols[col] = true
+	return t
+}
+
+// SetMergeMark sets a mark, such as a ditto mark ("〃" or `"`), shown in
+// place of values merged by SetMergeRepeated instead of leaving them blank.
+// An empty mark restores blanks.
+func (t *Table) SetMergeMark(mark string) *Table {
+	t.mergeMark = []byte(mark)
+	if mark == "" {
+		t.mergeMark = nil
+	}
+	return t
+}
+
+// mergeKind says why a cell is merged into the one above it.
+type mergeKind uint8
+
+const (
+	mergeNone    mergeKind = iota
+	mergeRepeat            // repeats the value above, see SetMergeRepeated
+	mergeCovered           // under a cell with a RowSpan
+)
+
+// merges works out, by position in rows, which cells of each data row are
+// merged into the row above, and the column spans of rows under a cell that
+// spans both rows and columns. Rows with nothing merged, and separators, are
+// nil in cells, and rows keeping their own spans are nil in spans; both are
+// nil when nothing is merged at all.
+func (t *Table) merges() (cells [][]mergeKind, spans [][]int) {
+	if len(t.mergeCols) == 0 && t.rowDown == nil {
+		return nil, nil
+	}
+
+	cells = make([][]mergeKind, len(t.rows))
+	mark := func(pos, col int, kind mergeKind) {
+		if cells[pos] == nil {
+			cells[pos] = make([]mergeKind, len(t.headers))
+		}
+		cells[pos][col] = kind
+	}
+
+	// Cells with a RowSpan cover their columns in the next rows.
+	left := make([]int, len(t.headers))  // rows each still has to cover
+	width := make([]int, len(t.headers)) // columns it covers
+	for pos := range t.rows {
+		if t.rowKinds[pos] != rowData {
+			continue
+		}
+		for col, n := range left {
+			if n == 0 {
+				continue
+			}
+			for c := col; c < col+width[col]; c++ {
+				mark(pos, c, mergeCovered)
+			}
+			if width[col] > 1 {
+				spans = growTo(spans, pos+1)
+				if spans[pos] == nil {
+					spans[pos] = plainSpans(t.spansAt(pos), len(t.headers))
+				}
+				spans[pos][col] = width[col]
+				for c := col + 1; c < col+width[col]; c++ {
+					spans[pos][c] = 0
+				}
+			}
+			left[col]--
+		}
+		if pos < len(t.rowDown) {
+			own := t.spansAt(pos)
+			for col, n := range t.rowDown[pos] {
+				if n > 1 {
+					left[col] = n - 1
+					width[col] = 1
+					if own != nil {
+						width[col] = own[col]
+					}
+				}
+			}
+		}
+	}
+
+	prev := -1 // position of the last data row
+	for pos, row := range t.rows {
+		if t.rowKinds[pos] != rowData {
+			continue
+		}
+		if prev >= 0 && t.spansAt(pos) == nil && t.spansAt(prev) == nil {
+			for col := range t.headers {
+				if !t.mergeCols[col] {
+					continue
+				}
+				if string(row[col]) != string(t.rows[prev][col]) {
+					break // columns further right start new runs
+				}
+				if cells[pos] == nil || cells[pos][col] == mergeNone {
+					mark(pos, col, mergeRepeat)
+				}
+			}
+		}
+		prev = pos
+	}
+	return cells, spans
+}
+
+// plainSpans returns a copy of spans, or all ones for a plain row of n
+// columns.
+func plainSpans(spans []int, n int) []int {
+	if spans != nil {
+		return append([]int(nil), spans...)
+	}
+	out := make([]int, n)
+	for i := range out {
+		out[i] = 1
+	}
+	return out
+}
+
+// restartRuns returns merged without its repeats, for a row whose values
+// must be shown again, such as the first one under a repeated header.
+func restartRuns(merged []mergeKind) []mergeKind {
+	out := make([]mergeKind, len(merged))
+	for col, kind := range merged {
+		if kind != mergeRepeat {
+			out[col] = kind
+		}
+	}
+	return out
+}
+
+// mergedRow returns row with the cells marked in merged replaced by the
+// merge mark (repeats) or blanked (covered cells), reusing dst for storage,
+// and whether the result is still all printable ASCII.
+func (t *Table) mergedRow(dst, row [][]byte, merged []mergeKind, ascii bool) ([][]byte, bool) {
+	dst = append(dst[:0], row...)
+	for col, kind := range merged {
+		switch kind {
+		case mergeRepeat:
+			dst[col] = t.mergeMark
+			ascii = ascii && isPrintableASCII(t.mergeMark)
+		case mergeCovered:
+			dst[col] = nil
+		}
+	}
+	return dst, ascii
+}
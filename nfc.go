@@ -0,0 +1,256 @@
+// nfc.go
+
+package tables
+
+import (
+	"sort"
+	"sync"
+	"unicode/utf8"
+)
+
+//go:generate go run gen_nfc.go
+
+// Hangul syllable arithmetic from the Unicode standard, section 3.12.
+const (
+	hangulBase   = 0xAC00
+	hangulL      = 0x1100
+	hangulV      = 0x1161
+	hangulT      = 0x11A7
+	hangulLCount = 19
+	hangulVCount = 21
+	hangulTCount = 28
+	hangulNCount = hangulVCount * hangulTCount
+	hangulCount  = hangulLCount * hangulNCount
+)
+
+// nfcData holds the lookup maps built from nfc_tables.go on first use.
+var nfcData struct {
+	once    sync.Once
+	decomp  map[rune]nfcPair
+	compose map[[2]rune]rune
+	second  map[rune]bool // runes that can combine with a preceding starter
+}
+
+// SetNormalizeNFC turns Unicode NFC normalization of cell content on or off.
+// When on, text is normalized as it is added, so visually identical strings
+// built from different code point sequences (é as U+00E9, or as e followed by
+// U+0301) are stored identically: they then measure, sort and compare the
+// same.
+//
+// Turning it on also normalizes the headers, rows and footer already in the
+// table. Turning it off stops normalizing new content but leaves stored data
+// as it is. Rows of plain ASCII, which NFC never changes, are skipped.
+func (t *Table) SetNormalizeNFC(on bool) *Table {
+	t.nfc = on
+	if !on {
+		return t
+	}
+
+	t.thaw()
+	normalizeCells(t.headers)
+	for i, row := range t.rows {
+		if !t.rowASCII[i] {
+			normalizeCells(row)
+		}
+	}
+	normalizeCells(t.footer)
+	return t
+}
+
+// normalizeCells replaces every cell of row with its NFC form.
+func normalizeCells(row [][]byte) {
+	for i, cell := range row {
+		row[i] = normalizeNFC(cell)
+	}
+}
+
+// normalizeNFC returns b in Unicode Normalization Form C. b is returned
+// unchanged, without allocating, when it contains nothing normalization could
+// affect. Escape sequences are ASCII and pass through untouched.
+func normalizeNFC(b []byte) []byte {
+	if isPrintableASCII(b) {
+		return b
+	}
+	nfcData.once.Do(buildNFCData)
+
+	i := 0
+	for i < len(b) {
+		r, size := utf8.DecodeRune(b[i:])
+		if r >= utf8.RuneSelf && nfcAffects(r) {
+			break
+		}
+		i += size
+	}
+	if i == len(b) {
+		return b
+	}
+
+	// Normalize from the start of the run that contains the first affected
+	// rune: back up to the preceding starter it might combine with.
+	start := i
+	for start > 0 {
+		r, size := utf8.DecodeLastRune(b[:start])
+		start -= size
+		if combiningClass(r) == 0 {
+			break
+		}
+	}
+
+	runes := decomposeNFD(nil, b[start:])
+	sortCanonical(runes)
+	runes = composeNFC(runes)
+
+	out := make([]byte, start, len(b))
+	copy(out, b[:start])
+	for _, r := range runes {
+		out = utf8.AppendRune(out, r)
+	}
+	return out
+}
+
+// nfcAffects reports whether r might be changed by NFC or change the rune
+// before it.
+func nfcAffects(r rune) bool {
+	if _, ok := nfcData.decomp[r]; ok {
+		return true
+	}
+	return combiningClass(r) != 0 || nfcData.second[r] ||
+		(r >= hangulL && r < hangulL+hangulLCount) ||
+		(r >= hangulBase && r < hangulBase+hangulCount)
+}
+
+// buildNFCData fills nfcData from the generated tables.
+func buildNFCData() {
+	nfcData.decomp = make(map[rune]nfcPair, len(nfcComposing)+len(nfcDecomposing))
+	nfcData.compose = make(map[[2]rune]rune, len(nfcComposing))
+	nfcData.second = make(map[rune]bool)
+	for _, p := range nfcComposing {
+		nfcData.decomp[p.c] = p
+		nfcData.compose[[2]rune{p.a, p.b}] = p.c
+		nfcData.second[p.b] = true
+	}
+	for _, p := range nfcDecomposing {
+		nfcData.decomp[p.c] = p
+	}
+	for r := rune(hangulV); r < hangulV+hangulVCount; r++ {
+		nfcData.second[r] = true
+	}
+	for r := rune(hangulT + 1); r < hangulT+hangulTCount; r++ {
+		nfcData.second[r] = true
+	}
+}
+
+// combiningClass returns the canonical combining class of r.
+func combiningClass(r rune) uint8 {
+	if r < 0x0300 {
+		return 0
+	}
+	i := sort.Search(len(nfcCombiningClasses), func(i int) bool {
+		return nfcCombiningClasses[i].hi >= r
+	})
+	if i < len(nfcCombiningClasses) && nfcCombiningClasses[i].lo <= r {
+		return nfcCombiningClasses[i].class
+	}
+	return 0
+}
+
+// decomposeNFD appends the full canonical decomposition of b to dst.
+func decomposeNFD(dst []rune, b []byte) []rune {
+	for len(b) > 0 {
+		r, size := utf8.DecodeRune(b)
+		b = b[size:]
+		dst = appendDecomposed(dst, r)
+	}
+	return dst
+}
+
+// appendDecomposed appends the full canonical decomposition of r to dst.
+func appendDecomposed(dst []rune, r rune) []rune {
+	if s := r - hangulBase; s >= 0 && s < hangulCount {
+		dst = append(dst, hangulL+s/hangulNCount, hangulV+(s%hangulNCount)/hangulTCount)
+		if t := s % hangulTCount; t != 0 {
+			dst = append(dst, hangulT+t)
+		}
+		return dst
+	}
+
+	p, ok := nfcData.decomp[r]
+	if !ok {
+		return append(dst, r)
+	}
+	dst = appendDecomposed(dst, p.a)
+	if p.b != 0 {
+		dst = appendDecomposed(dst, p.b)
+	}
+	return dst
+}
+
+// sortCanonical puts each run of combining marks into canonical order: a
+// stable sort by combining class, leaving starters (class 0) in place.
+func sortCanonical(runes []rune) {
+	for i := 0; i < len(runes); {
+		if combiningClass(runes[i]) == 0 {
+			i++
+			continue
+		}
+		j := i
+		for j < len(runes) && combiningClass(runes[j]) != 0 {
+			j++
+		}
+		run := runes[i:j]
+		sort.SliceStable(run, func(a, b int) bool {
+			return combiningClass(run[a]) < combiningClass(run[b])
+		})
+		i = j
+	}
+}
+
+// composeNFC applies canonical composition to a decomposed, canonically
+// ordered sequence in place and returns the shortened slice.
+func composeNFC(runes []rune) []rune {
+	if len(runes) == 0 {
+		return runes
+	}
+
+	starterPos := 0
+	lastClass := int(combiningClass(runes[0]))
+	if lastClass != 0 {
+		starterPos = -1 // no starter to compose with yet
+		lastClass = 256
+	}
+
+	out := 1
+	for _, r := range runes[1:] {
+		class := int(combiningClass(r))
+		if starterPos >= 0 && (lastClass < class || lastClass == 0) {
+			if c, ok := composePair(runes[starterPos], r); ok {
+				runes[starterPos] = c
+				continue
+			}
+		}
+		if class == 0 {
+			starterPos = out
+		}
+		lastClass = class
+		runes[out] = r
+		out++
+	}
+	return runes[:out]
+}
+
+// composePair returns the primary composite of a followed by b, if any.
+func composePair(a, b rune) (rune, bool) {
+	if l := a - hangulL; l >= 0 && l < hangulLCount {
+		if v := b - hangulV; v >= 0 && v < hangulVCount {
+			return hangulBase + (l*hangulVCount+v)*hangulTCount, true
+		}
+	}
+	if s := a - hangulBase; s >= 0 && s < hangulCount && s%hangulTCount == 0 {
+		if t := b - hangulT; t > 0 && t < hangulTCount {
+			return a + t, true
+		}
+	}
+
+	c, ok := nfcData.compose[[2]rune{a, b}]
+	return c, ok
+}
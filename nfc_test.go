@@ -0,0 +1,117 @@
+// nfc_test.go
+
+package tables
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// TestNormalizeNFC checks normalizeNFC on a few cases of each kind of
+// normalization, without the conformance file.
+func TestNormalizeNFC(t *testing.T) {
+	for _, tc := range []struct{ name, in, want string }{
+		{"ascii", "plain text", "plain text"},
+		{"composed é", "caf\u00E9", "caf\u00E9"},
+		{"decomposed é", "cafe\u0301", "caf\u00E9"},
+		{"Hangul LV", "\u1100\u1161", "\uAC00"},
+		{"Hangul LVT", "\u1100\u1161\u11A8", "\uAC01"},
+		{"Hangul LV + T", "\uAC00\u11A8", "\uAC01"},
+		{"reordered marks", "q\u0307\u0323", "q\u0323\u0307"},
+		{"reordered then composed", "\u1E0B\u0323", "\u1E0D\u0307"},
+		{"singleton", "\u212B", "\u00C5"},
+		{"composition exclusion", "\u0958", "\u0915\u093C"},
+		{"excluded pair kept apart", "\u0915\u093C", "\u0915\u093C"},
+	} {
+		if got := string(normalizeNFC([]byte(tc.in))); got != tc.want {
+			t.Errorf("%s: NFC(%+q) = %+q, want %+q", tc.name, tc.in, got, tc.want)
+		}
+	}
+}
+
+// TestNormalizationConformance checks normalizeNFC against the conformance
+// file of the Unicode version nfc_tables.go was generated from. The file is
+// too large to keep in the repository; fetch it with
+//
+//	go run gen_nfc.go -test
+func TestNormalizationConformance(t *testing.T) {
+	f, err := os.Open("testdata/NormalizationTest.txt")
+	if os.IsNotExist(err) {
+		t.Skip("testdata/NormalizationTest.txt missing; run go run gen_nfc.go -test")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	nfc := func(s string) string { return string(normalizeNFC([]byte(s))) }
+	part1 := map[rune]bool{} // Runes listed in part 1; all others are unchanged by NFC
+	part, line := "", 0
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line++
+		text, _, _ := strings.Cut(sc.Text(), "#")
+		if text = strings.TrimSpace(text); text == "" {
+			continue
+		}
+		if strings.HasPrefix(text, "@") {
+			part = text
+			continue
+		}
+
+		fields := strings.Split(text, ";")
+		if len(fields) < 5 {
+			t.Fatalf("line %d: %d fields", line, len(fields))
+		}
+		var c [5]string
+		for i := range c {
+			c[i] = parseCodePoints(t, line, fields[i])
+		}
+		if part == "@Part1" {
+			r, _ := utf8.DecodeRuneInString(c[0])
+			part1[r] = true
+		}
+
+		// c2 == toNFC(c1) == toNFC(c2) == toNFC(c3), c4 == toNFC(c4) == toNFC(c5)
+		for _, check := range []struct{ want, in string }{
+			{c[1], c[0]}, {c[1], c[1]}, {c[1], c[2]}, {c[3], c[3]}, {c[3], c[4]},
+		} {
+			if got := nfc(check.in); got != check.want {
+				t.Errorf("line %d: NFC(%+q) = %+q, want %+q", line, check.in, got, check.want)
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(part1) == 0 {
+		t.Fatal("no @Part1 lines")
+	}
+
+	for r := rune(0); r <= utf8.MaxRune; r++ {
+		if part1[r] || !utf8.ValidRune(r) {
+			continue
+		}
+		if s := string(r); nfc(s) != s {
+			t.Errorf("NFC(%U) = %+q, want it unchanged", r, nfc(s))
+		}
+	}
+}
+
+// parseCodePoints parses a space-separated list of hex code points.
+func parseCodePoints(t *testing.T, line int, field string) string {
+	t.Helper()
+	var b strings.Builder
+	for _, hex := range strings.Fields(field) {
+		r, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			t.Fatalf("line %d: code point %q", line, hex)
+		}
+		b.WriteRune(rune(r))
+	}
+	return b.String()
+}
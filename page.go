@@ -0,0 +1,157 @@
+// page.go
+
+package tables
+
+import (
+	"fmt"
+)
+
+// SetPageSize splits the table into pages of n data rows for RenderPage, for
+// pagers and reports printed in chunks. n <= 0 turns paging off, making the
+// whole table one page.
+func (t *Table) SetPageSize(n int) *Table {
+	t.pageSize = max(n, 0)
+	return t
+}
+
+// Pages returns the number of pages RenderPage can render: at least 1, even
+// for an empty table, so the header can always be shown. Only rows SetFilter
+// keeps are counted, along with GroupBy banners and subtotals.
+func (t *Table) Pages() int {
+	rows := t.warm().filtered().grouped().dataRowsFrom(0)
+	if t.pageSize == 0 || rows == 0 {
+		return 1
+	}
+	return (rows + t.pageSize - 1) / t.pageSize
+}
+
+// RenderPage renders page number page, counting from 0, as a complete table
+// of its own: the title and header repeated, then the page's data rows and
+// the separators between them, with the footer on the last page only.
+// Columns are as wide as the whole table needs, so every page lines up with
+// the others. Colors and SetAutoIndex numbers follow the rows as if the table
+// were rendered in one piece. A page out of range renders as "".
+//
+// Example:
+//
+//	t.SetPageSize(20)
+//	for p := range t.Pages() {
+//	    fmt.Print(t.RenderPage(p))
+//	    waitForKey()
+//	}
+func (t *Table) RenderPage(page int) string {
+	if page < 0 || page >= t.Pages() {
+		return ""
+	}
+	if t.pageSize == 0 {
+		return t.String()
+	}
+	cp := *t
+	cp.page = page + 1
+	return cp.String()
+}
+
+// paged returns t itself, or for RenderPage a copy holding the rows of the
+// page. Template lines go with the row above them and aren't counted.
+func (t *Table) paged() *Table {
+	if t.page == 0 {
+		return t
+	}
+	first := (t.page - 1) * t.pageSize
+	last := first + t.pageSize // data row index past the page
+
+	var keep []int // positions in rows
+	idx, numbered := 0, 0
+	for pos, kind := range t.rowKinds {
+		if kind == rowData && !t.isBelow(pos) {
+			idx++
+			if idx <= first && !t.isAux(pos) {
+				numbered++ // SetAutoIndex numbers before the page
+			}
+		}
+		if idx > first && idx <= last {
+			keep = append(keep, pos)
+		}
+	}
+	for len(keep) > 0 && t.rowKinds[keep[len(keep)-1]] != rowData {
+		keep = keep[:len(keep)-1] // the separator after the last row
+	}
+
+	cp := *t
+	cp.page = 0
+	cp.locked = t.naturalWidths()
+	cp.maxRows = 0 // pages show every row
+	cp.permuteRows(keep)
+	cp.indexStart = t.indexStart + numbered
+	if last < idx {
+		cp.footer = nil
+	}
+	return &cp
+}
+
+// SetMaxRows renders only the first n data rows, followed by a line inside
+// the borders counting the rest, so a huge result set doesn't flood the
+// terminal:
+//
+//	│ web-9     │ running │
+//	│ … and 257 more rows   │
+//	└─────────────────────┘
+//
+// The line spans the table and is drawn in the theme's muted color. The
+// footer is still shown, and SetColumnAggregate summaries still cover every
+// row. Rows left out by SetFilter aren't counted, and RenderPage, which shows
+// every row a page at a time, ignores the limit. n <= 0 renders every row.
+func (t *Table) SetMaxRows(n int) *Table {
+	t.maxRows = max(n, 0)
+	return t
+}
+
+// capped returns t itself, or with SetMaxRows a copy holding the first rows
+// and the line counting the others. RenderPage's pages are left whole.
+func (t *Table) capped() *Table {
+	if t.maxRows == 0 || t.page != 0 {
+		return t
+	}
+
+	var keep []int // positions in rows
+	idx, hidden := 0, 0
+	for pos, kind := range t.rowKinds {
+		if kind == rowData {
+			idx++
+		}
+		if idx <= t.maxRows {
+			keep = append(keep, pos)
+		} else if kind == rowData && !t.isAux(pos) {
+			hidden++
+		}
+	}
+	cp := *t
+	cp.maxRows = 0
+	if hidden == 0 {
+		return &cp
+	}
+	for len(keep) > 0 && t.rowKinds[keep[len(keep)-1]] != rowData {
+		keep = keep[:len(keep)-1] // the separator after the last row shown
+	}
+	cp.permuteRows(keep)
+
+	more := make([][]byte, len(t.headers))
+	spans := make([]int, len(t.headers))
+	for i := range more {
+		more[i] = []byte{}
+	}
+	if hidden == 1 {
+		more[0] = []byte("… and 1 more row")
+	} else {
+		more[0] = fmt.Appendf(nil, "… and %d more rows", hidden)
+	}
+	spans[0] = len(t.headers)
+	pos := len(cp.rows)
+	cp.rows = append(cp.rows, more)
+	cp.rowKinds = append(cp.rowKinds, rowData)
+	cp.rowASCII = append(cp.rowASCII, false)
+	cp.setSpans(pos, spans)
+	cp.setAux(pos)
+	cp.SetRowColor(min(idx, t.maxRows), NewColor().WithStyle(t.palette().Muted))
+	return &cp
+}
@@ -0,0 +1,49 @@
+// paste.go
+
+package tables
+
+import (
+	"strings"
+)
+
+// pasteWidth is the widest line RenderForPaste produces.
+const pasteWidth = 80
+
+// RenderForPaste renders the table as plain text that survives being pasted
+// into an email, a chat message or a code review comment, where anything
+// beyond ASCII and spaces tends to get mangled:
+//
+//   - Only printable ASCII: StyleASCII borders (unless the style already is
+//     ASCII, like StyleNone), wide glyphs emulated as with SetWideEmulation,
+//     other non-ASCII characters replaced with '?', escape sequences removed
+//   - Tabs in cells become spaces, so indentation is spaces only
+//   - Trailing spaces are trimmed from every line
+//   - Lines are at most 80 characters: columns are narrowed widest first, as
+//     for a narrow terminal, and whatever still doesn't fit is cut off
+//
+// The table itself is left unchanged. Use RenderCompat to also learn which
+// cells needed replacements.
+func (t *Table) RenderForPaste() string {
+	cp := *t
+	cp.compatMode = true
+	cp.compatAllow = nil
+	cp.pasteMode = true
+	cp.wideEmulation = true
+	cp.fitWidth = pasteWidth
+	if !cp.compatStyle() {
+		cp.style = StyleASCII
+	}
+
+	out := StripANSI(cp.view().String())
+	var sb strings.Builder
+	sb.Grow(len(out))
+	for line := range strings.Lines(out) {
+		line = strings.TrimSuffix(line, "\n")
+		if len(line) > pasteWidth {
+			line = line[:pasteWidth]
+		}
+		sb.WriteString(strings.TrimRight(line, " "))
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
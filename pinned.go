@@ -0,0 +1,205 @@
+// pinned.go
+
+package tables
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"sync"
+)
+
+// Pinned keeps a table on the bottom lines of the terminal while other output
+// scrolls above it: the classic progress footer. It sets a scroll region
+// covering the rest of the screen, so anything written through Pinned (or
+// with the cursor left where Pinned leaves it) scrolls without disturbing the
+// table.
+//
+// Pinned is an io.Writer for that log output, so it can be handed to
+// log.SetOutput or slog.NewTextHandler. Writes and updates are serialized and
+// safe to call from different goroutines; the table itself must still not be
+// changed while Update runs.
+type Pinned struct {
+	t  *Table
+	w  io.Writer
+	fd uintptr
+
+	mu         sync.Mutex
+	cols, rows int      // terminal size at the last update
+	bottom     int      // last line of the scroll region; the table starts below it
+	lines      [][]byte // table lines on screen, nil to redraw all
+	front      bytes.Buffer
+	back       bytes.Buffer
+}
+
+// Pin reserves the bottom of the terminal behind w for t and draws it there.
+// Call Update after changing the table, write log output to the returned
+// Pinned, and Close it when done. The region grows and shrinks with the
+// table, which is clipped to leave at least one line for output above it.
+// Columns are narrowed to fit the terminal width, and a resize redraws the
+// screen for the new size.
+//
+// w must be a terminal; otherwise Pin returns an error and nothing is
+// written, so callers can fall back to plain output.
+//
+// Example:
+//
+//	pin, err := t.Pin(os.Stdout)
+//	if err != nil {
+//	    return err
+//	}
+//	defer pin.Close()
+//	log.SetOutput(pin)
+//	for job := range jobs {
+//	    log.Printf("finished %s", job.Name)
+//	    t.SetCell(job.Row, 2, "done")
+//	    pin.Update()
+//	}
+func (t *Table) Pin(w io.Writer) (*Pinned, error) {
+	f, ok := w.(fdWriter)
+	if !ok || !isTerminalFd(f.Fd()) {
+		return nil, errNoTerminal
+	}
+	p := &Pinned{t: t, w: w, fd: f.Fd()}
+	return p, p.Update()
+}
+
+// Write writes b to the scrolling area above the table.
+func (p *Pinned) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.w.Write(b)
+}
+
+// Update redraws the table, resizing the pinned area if its height changed.
+// Only lines that differ from the ones on screen are written.
+func (p *Pinned) Update() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	buf := p.t.bufPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer p.t.bufPool.Put(buf)
+
+	cols, rows, ok := terminalSize(p.fd)
+	if !ok {
+		cols, rows = 80, 24
+	}
+	resized := p.rows != 0 && (cols != p.cols || rows != p.rows)
+	if resized {
+		// Lines have been rewrapped to the new size and the old region no
+		// longer matches the screen: start over on a cleared one.
+		buf.WriteString("\033[r\033[2J\033[H")
+	}
+	p.cols, p.rows = cols, rows
+
+	p.back.Reset()
+	fitted := *p.t
+	fitted.fitWidth = cols
+	fitted.view().render(&p.back)
+	next := splitLines(nil, p.back.Bytes())
+	if len(next) > rows-1 {
+		next = next[:max(rows-1, 0)]
+	}
+	bottom := rows - len(next)
+
+	switch {
+	case p.bottom == 0 || resized:
+		p.reserve(buf, len(next), bottom)
+	case bottom < p.bottom:
+		p.grow(buf, p.bottom-bottom, bottom)
+	case bottom > p.bottom:
+		p.shrink(buf, bottom)
+	}
+	p.bottom = bottom
+
+	buf.WriteString("\0337")
+	for i, line := range next {
+		if i < len(p.lines) && bytes.Equal(line, p.lines[i]) {
+			continue
+		}
+		moveToLine(buf, bottom+1+i)
+		buf.Write(clipLine(line, cols, p.t.widthFunc))
+		buf.WriteString("\033[K")
+	}
+	buf.WriteString("\0338")
+
+	p.lines = next
+	p.front, p.back = p.back, p.front
+
+	_, err := buf.WriteTo(p.w)
+	return err
+}
+
+// Close gives the whole screen back to normal output, leaving the last frame
+// of the table in place with the cursor on the line below it.
+func (p *Pinned) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var buf bytes.Buffer
+	buf.WriteString("\033[r")
+	moveToLine(&buf, p.rows)
+	buf.WriteByte('\n')
+	_, err := buf.WriteTo(p.w)
+	return err
+}
+
+// reserve makes room for n table lines below the cursor, scrolling the screen
+// if needed, and sets the scroll region to end at line bottom.
+func (p *Pinned) reserve(buf *bytes.Buffer, n, bottom int) {
+	for range n {
+		buf.WriteByte('\n')
+	}
+	if n > 0 {
+		buf.WriteString("\033[")
+		buf.WriteString(strconv.Itoa(n))
+		buf.WriteByte('A')
+	}
+	p.setRegion(buf, bottom)
+	p.lines = nil
+}
+
+// grow moves the region's bottom up by k lines to make room for a taller
+// table. The output above scrolls up with the cursor so none of it is
+// covered.
+func (p *Pinned) grow(buf *bytes.Buffer, k, bottom int) {
+	buf.WriteString("\0337")
+	moveToLine(buf, p.bottom)
+	for range k {
+		buf.WriteByte('\n')
+	}
+	buf.WriteString("\0338\033[")
+	buf.WriteString(strconv.Itoa(k))
+	buf.WriteByte('A')
+	p.setRegion(buf, bottom)
+	p.lines = nil
+}
+
+// shrink moves the region's bottom down to line bottom, clearing the lines
+// the shorter table no longer covers.
+func (p *Pinned) shrink(buf *bytes.Buffer, bottom int) {
+	buf.WriteString("\0337")
+	for line := p.bottom + 1; line <= bottom; line++ {
+		moveToLine(buf, line)
+		buf.WriteString("\033[2K")
+	}
+	buf.WriteString("\0338")
+	p.setRegion(buf, bottom)
+	p.lines = nil
+}
+
+// setRegion limits scrolling to lines 1 through bottom. Setting a region
+// moves the cursor to the top left, so the cursor is saved around it.
+func (p *Pinned) setRegion(buf *bytes.Buffer, bottom int) {
+	buf.WriteString("\0337\033[1;")
+	buf.WriteString(strconv.Itoa(bottom))
+	buf.WriteString("r\0338")
+}
+
+// moveToLine moves the cursor to the start of screen line n (1-based).
+func moveToLine(buf *bytes.Buffer, n int) {
+	buf.WriteString("\033[")
+	buf.WriteString(strconv.Itoa(n))
+	buf.WriteString(";1H")
+}
@@ -0,0 +1,103 @@
+// pivot.go
+
+package tables
+
+// Pivot returns a new table cross-tabulating this one, for turning long
+// listings such as metric dumps ("host, metric, value" per line) into a grid.
+// Each distinct value of column rowKey becomes a row and each distinct value
+// of column colKey a column, both in the order they first occur. The cell
+// where they meet summarizes column valueCol over the rows with both values,
+// computed like SetColumnAggregate; with AggNone it holds the value itself,
+// the last one if several rows match. Cells no row maps to are empty.
+//
+// Only data rows SetFilter keeps are read, and rows added with
+// AddSpannedRow are skipped. Keys are compared with ANSI sequences ignored.
+// The new table has the border style, width function and escape policy of
+// this one, with the summary columns right-aligned. An out-of-range column
+// leaves the new table empty with ErrColumnOutOfRange recorded in its Err.
+//
+// Example:
+//
+//	// host, metric, value
+//	t.Pivot(0, 1, 2, tables.AggAvg).Print()
+//
+//	│ Host  │ cpu │ mem │
+//	├───────┼─────┼─────┤
+//	│ web-1 │  42 │  71 │
+//	│ web-2 │  17 │  64 │
+func (t *Table) Pivot(rowKey, colKey, valueCol int, agg Aggregate) *Table {
+	for _, col := range []int{rowKey, colKey, valueCol} {
+		if col < 0 || col >= len(t.headers) {
+			p := New()
+			p.setErr(columnError(col, len(t.headers)))
+			return p
+		}
+	}
+	src := t.warm().filtered()
+
+	// Distinct keys in order of first appearance, and the positions in rows
+	// of each pair of them.
+	var rowKeys, colKeys []string
+	rowLabel := make(map[string][]byte)
+	colIndex := make(map[string]int)
+	cells := make(map[string]map[string][]int)
+	for pos, kind := range src.rowKinds {
+		if kind != rowData || src.spansAt(pos) != nil || src.isAux(pos) {
+			continue
+		}
+		row := src.rows[pos]
+		r, c := cellString(row, rowKey), cellString(row, colKey)
+		if _, ok := cells[r]; !ok {
+			rowKeys = append(rowKeys, r)
+			rowLabel[r] = cellAt(row, rowKey)
+			cells[r] = make(map[string][]int)
+		}
+		if _, ok := colIndex[c]; !ok {
+			colIndex[c] = len(colKeys)
+			colKeys = append(colKeys, c)
+		}
+		cells[r][c] = append(cells[r][c], pos)
+	}
+
+	headers := make([]string, 1+len(colKeys))
+	headers[0] = string(t.headers[rowKey])
+	copy(headers[1:], colKeys)
+	p := t.derive(headers...)
+	if agg != AggNone {
+		for col := 1; col < len(headers); col++ {
+			p.aligns[col] = AlignRight
+		}
+	}
+
+	for _, r := range rowKeys {
+		row := make([][]byte, len(headers))
+		row[0] = rowLabel[r]
+		for c, positions := range cells[r] {
+			var v []byte
+			if agg == AggNone {
+				v = cellAt(src.rows[positions[len(positions)-1]], valueCol)
+			} else {
+				v = src.aggregate(positions, valueCol, agg)
+			}
+			row[1+colIndex[c]] = v
+		}
+		for i := range row {
+			if row[i] == nil {
+				row[i] = []byte{}
+			}
+		}
+		p.appendRow(row)
+	}
+	return p
+}
+
+// derive returns a new table with the given headers and the settings of t
+// that decide how its cells look: border style, width function and escape
+// policy.
+func (t *Table) derive(headers ...string) *Table {
+	d := NewFromStrings(headers...)
+	d.style = t.style
+	d.widthFunc, d.baseWidth, d.emojiWide, d.asciiUnit = t.widthFunc, t.baseWidth, t.emojiWide, t.asciiUnit
+	d.escapePolicy = t.escapePolicy
+	return d
+}
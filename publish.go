@@ -0,0 +1,101 @@
+// publish.go
+
+package tables
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"time"
+)
+
+// Snapshot is a table rendered once as text, HTML and JSON, as returned by
+// Publish. It never changes after Publish returns, so any number of
+// goroutines may read it, and serve it with the tableshttp package, without
+// locking, while the table it came from goes on being updated.
+type Snapshot struct {
+	text, html, json string
+	etag             string // Hash of the three, see ETag
+	at               time.Time
+}
+
+// snapshotJSON is the JSON form of a snapshot.
+type snapshotJSON struct {
+	Title   string     `json:"title,omitempty"`
+	Headers []string   `json:"headers"`
+	Rows    [][]string `json:"rows"`
+	Footer  []string   `json:"footer,omitempty"`
+}
+
+// Publish renders the table as text, as String does, as HTML, as HTML does
+// with opts, and as JSON, and returns the three in a Snapshot for a web UI
+// or API to hand out. A server keeps the latest snapshot in an
+// atomic.Pointer, publishing a new one after each change, and serves it from
+// any number of requests at no further cost:
+//
+//	var current atomic.Pointer[tables.Snapshot]
+//	current.Store(t.Publish())
+//
+//	http.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+//	    tableshttp.ServeSnapshot(w, r, current.Load())
+//	})
+//
+// The JSON is an object with the "headers", the data "rows" as arrays of
+// cell text and, when set, the "title" and "footer", all with ANSI
+// sequences stripped. Like the other exporters it holds every data row,
+// SetFilter notwithstanding. The table must not be changed while Publish
+// runs.
+func (t *Table) Publish(opts ...HTMLOption) *Snapshot {
+	s := &Snapshot{
+		text: t.String(),
+		html: t.HTML(opts...),
+		at:   time.Now(),
+	}
+
+	w := t.warm()
+	j := snapshotJSON{Title: StripANSI(string(w.title)), Headers: []string{}, Rows: [][]string{}}
+	for _, h := range w.headers {
+		j.Headers = append(j.Headers, StripANSI(string(h)))
+	}
+	for pos, kind := range w.rowKinds {
+		if kind != rowData {
+			continue
+		}
+		row := make([]string, len(w.headers))
+		for col := range row {
+			row[col] = cellString(w.rows[pos], col)
+		}
+		j.Rows = append(j.Rows, row)
+	}
+	if w.footer != nil {
+		j.Footer = make([]string, len(w.headers))
+		for col := range j.Footer {
+			j.Footer[col] = cellString(w.footer, col)
+		}
+	}
+	data, _ := json.Marshal(j) // only strings, which always encode
+	s.json = string(data)
+
+	h := fnv.New64a()
+	for _, part := range []string{s.text, s.html, s.json} {
+		fmt.Fprintf(h, "%d:%s", len(part), part)
+	}
+	s.etag = fmt.Sprintf("%016x", h.Sum64())
+	return s
+}
+
+// Text returns the table as String rendered it.
+func (s *Snapshot) Text() string { return s.text }
+
+// HTML returns the table as HTML rendered it.
+func (s *Snapshot) HTML() string { return s.html }
+
+// JSON returns the table as a JSON object, described under Publish.
+func (s *Snapshot) JSON() string { return s.json }
+
+// Time returns when the snapshot was published.
+func (s *Snapshot) Time() time.Time { return s.at }
+
+// ETag returns a hash of the three renderings, which changes whenever any of
+// them does, for caches and HTTP ETag headers.
+func (s *Snapshot) ETag() string { return s.etag }
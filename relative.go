@@ -0,0 +1,85 @@
+// relative.go
+
+package tables
+
+import (
+	"strconv"
+	"time"
+)
+
+// ColumnKind selects how a column's values are shown in the text output.
+type ColumnKind int
+
+const (
+	// KindText shows values as stored (the default).
+	KindText ColumnKind = iota
+	// KindRelativeTime shows timestamps relative to the time of rendering,
+	// such as "2m ago" or "in 3h".
+	KindRelativeTime
+)
+
+// SetColumnKind sets how column col's values are shown.
+//
+// With KindRelativeTime, cells holding a timestamp (a time.Time, or text in
+// RFC 3339, "2006-01-02 15:04:05" or the column's SetDateLocale form) are
+// shown as the time from or until the moment the table is rendered, in the
+// largest whole unit: "now", "45s ago", "2m ago", "in 3h", "5d ago", "1y
+// ago". A time.Time counts from the instant it holds, whatever its zone and
+// the column's locale; text without a zone is read as UTC, and other cells
+// are shown as they are. The values are worked out on every render, so a
+// Live display shows them aging on each Update even when nothing in the
+// table changed; call Update on a timer to keep them current. Only the text
+// output is affected; exports write the stored timestamps.
+//
+// Example:
+//
+//	t.SetColumnKind(2, tables.KindRelativeTime).
+//	    AddRow("deploy", "ok", time.Now().Add(-2*time.Minute))
+//
+//	│ deploy │ ok     │ 2m ago │
+func (t *Table) SetColumnKind(col int, kind ColumnKind) *Table {
+	if !t.checkColumn(col) {
+		return t
+	}
+	if kind == KindText {
+		delete(t.columnKinds, col)
+		return t
+	}
+	if t.columnKinds == nil {
+		t.columnKinds = make(map[int]ColumnKind)
+	}
+	t.columnKinds[col] = kind
+	return t
+}
+
+// relativeTime writes d, the time elapsed since a timestamp (negative for
+// one in the future), in its largest whole unit.
+func relativeTime(d time.Duration) string {
+	past := d >= 0
+	if !past {
+		d = -d
+	}
+
+	var n time.Duration
+	var unit string
+	switch {
+	case d < time.Second:
+		return "now"
+	case d < time.Minute:
+		n, unit = d/time.Second, "s"
+	case d < time.Hour:
+		n, unit = d/time.Minute, "m"
+	case d < 24*time.Hour:
+		n, unit = d/time.Hour, "h"
+	case d < 365*24*time.Hour:
+		n, unit = d/(24*time.Hour), "d"
+	default:
+		n, unit = d/(365*24*time.Hour), "y"
+	}
+
+	s := strconv.FormatInt(int64(n), 10) + unit
+	if past {
+		return s + " ago"
+	}
+	return "in " + s
+}
@@ -0,0 +1,133 @@
+// roff.go
+
+package tables
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+// Tbl returns the table as input for the tbl preprocessor, to embed in a man
+// page built from the same code as the CLI it documents:
+//
+//	.TS
+//	box;
+//	l r.
+//	\fBName\fR<TAB>\fBScore\fR
+//	_
+//	Alice<TAB>95
+//	.TE
+//
+// Columns keep their alignment (l, c or r), fields are separated by tabs, and
+// the header, and the footer if set, are set in bold below or above a rule.
+// Separator rows become rules as well. ANSI sequences are stripped, column
+// max widths apply, and cell text is escaped so it can't be read as a roff
+// request, a tbl rule or a text block; tabs and line breaks in cells become
+// spaces.
+//
+// Example:
+//
+//	f, _ := os.Create("man/mytool.1")
+//	fmt.Fprint(f, manHeader)
+//	t.WriteTblTo(f)
+func (t *Table) Tbl() string {
+	t = t.warm()
+	if len(t.headers) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	t.writeTbl(&sb)
+	return sb.String()
+}
+
+// WriteTblTo writes the tbl form of the table to w. See Tbl.
+func (t *Table) WriteTblTo(w io.Writer) (int64, error) {
+	t = t.warm()
+	if len(t.headers) == 0 {
+		return 0, nil
+	}
+
+	buf := t.bufPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer t.bufPool.Put(buf)
+
+	t.writeTbl(buf)
+	return buf.WriteTo(w)
+}
+
+// writeTbl renders the tbl block into sb.
+func (t *Table) writeTbl(sb io.StringWriter) {
+	sb.WriteString(".TS\nbox;\n")
+	for i, a := range t.aligns {
+		if i > 0 {
+			sb.WriteString(" ")
+		}
+		sb.WriteString(tblAlign(a))
+	}
+	sb.WriteString(".\n")
+
+	t.tblLine(sb, t.headers, false, true)
+	sb.WriteString("_\n")
+	for i, row := range t.rows {
+		if t.rowKinds[i] == rowSeparator {
+			sb.WriteString("_\n")
+			continue
+		}
+		t.tblLine(sb, row, true, false)
+	}
+	if t.footer != nil {
+		sb.WriteString("_\n")
+		t.tblLine(sb, t.footer, false, true)
+	}
+	sb.WriteString(".TE\n")
+}
+
+// tblLine writes one tab-separated data line. Data rows are truncated to the
+// column max widths; bold cells are wrapped in \fB...\fR.
+func (t *Table) tblLine(sb io.StringWriter, row [][]byte, data, bold bool) {
+	for j := range t.headers {
+		if j > 0 {
+			sb.WriteString("\t")
+		}
+		var cell string
+		if j < len(row) {
+			cell = StripANSI(string(row[j]))
+			if data && t.maxWidths[j] > 0 {
+				cell = TruncateToWidth(cell, t.maxWidths[j])
+			}
+		}
+		if cell == "" {
+			continue
+		}
+
+		cell = tblEscaper.Replace(cell)
+		if strings.ContainsAny(cell[:1], ".'_=") || strings.HasPrefix(cell, "T{") {
+			// Keep a leading dot or quote from being read as a request, and a
+			// leading _, = or T{ as a rule or text block.
+			cell = `\&` + cell
+		}
+		if bold {
+			cell = `\fB` + cell + `\fR`
+		}
+		sb.WriteString(cell)
+	}
+	sb.WriteString("\n")
+}
+
+// tblEscaper escapes backslashes for roff and turns the characters that would
+// split a field or a line into spaces.
+var tblEscaper = strings.NewReplacer(`\`, `\e`, "\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+// tblAlign returns the tbl column key for a.
+func tblAlign(a Align) string {
+	switch a {
+	case AlignCenter:
+		return "c"
+	case AlignRight:
+		return "r"
+	default:
+		return "l"
+	}
+}
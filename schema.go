@@ -0,0 +1,154 @@
+// schema.go
+
+package tables
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ColumnType is the kind of value a schema Column holds.
+type ColumnType int
+
+const (
+	ColumnString ColumnType = iota // any text
+	ColumnInt                      // an integer, e.g. "-42"
+	ColumnFloat                    // a number, e.g. "3.14" or "1e6"
+	ColumnBool                     // "true", "false", "1", "0", "t", "f" (any case)
+	ColumnDate                     // a date or time in a layout CSVSniffTypes recognizes
+)
+
+// String returns the name used for t in error messages.
+func (t ColumnType) String() string {
+	switch t {
+	case ColumnInt:
+		return "an integer"
+	case ColumnFloat:
+		return "a number"
+	case ColumnBool:
+		return "a boolean"
+	case ColumnDate:
+		return "a date"
+	default:
+		return "text"
+	}
+}
+
+// Column describes one column of the data an importer expects; see
+// CSVSchema.
+type Column struct {
+	Name     string
+	Type     ColumnType
+	Required bool // empty values are errors; otherwise they pass any type
+}
+
+// CSVSchema validates every data record against schema, one Column per field
+// in order. A record with a different number of fields, a value that doesn't
+// parse as its column's type, or an empty required value is left out of the
+// table instead of being padded, cut or stored as is, and goes to *rejected:
+// a table with the columns Line, Error and Record, listing the input line,
+// what was wrong, and the record's fields joined by the delimiter. Rejected is
+// set even when every record passes, so it can be checked with NumRows; pass
+// nil to drop bad records without a report.
+//
+// Headers come from the input as usual. When there are none to read (with
+// CSVSkipHeader, or CSVDetectHeader finding none) the schema's names are used
+// instead of "Col 1".."Col N".
+//
+// Example:
+//
+//	var rejected *tables.Table
+//	t, err := tables.NewFromCSV(f, tables.CSVSchema([]tables.Column{
+//	    {Name: "name", Required: true},
+//	    {Name: "age", Type: tables.ColumnInt},
+//	}, &rejected))
+//	if err != nil {
+//	    return err
+//	}
+//	if rejected.NumRows() > 0 {
+//	    rejected.Print()
+//	}
+func CSVSchema(schema []Column, rejected **Table) CSVOption {
+	return func(c *csvConfig) {
+		c.schema = schema
+		c.rejected = rejected
+	}
+}
+
+// startSchema creates the rejected table, if one was asked for.
+func (c *csvConfig) startSchema() {
+	if c.schema != nil && c.rejected != nil {
+		*c.rejected = NewFromStrings("Line", "Error", "Record")
+	}
+}
+
+// accept reports whether rec, read from line, passes the schema, recording
+// it as rejected if it doesn't.
+func (c *csvConfig) accept(line int, rec []string) bool {
+	if c.schema == nil {
+		return true
+	}
+	reason := checkRecord(c.schema, rec)
+	if reason == "" {
+		return true
+	}
+	if c.rejected != nil {
+		(*c.rejected).AddRow(line, reason, strings.Join(rec, string(c.delimiter)))
+	}
+	return false
+}
+
+// headerNames returns the headers for input without a header row: the schema
+// names if there is a schema, otherwise "Col 1".."Col n".
+func (c *csvConfig) headerNames(n int) []string {
+	if c.schema == nil {
+		return numberedHeaders(n)
+	}
+	names := make([]string, len(c.schema))
+	for i, col := range c.schema {
+		names[i] = col.Name
+	}
+	return names
+}
+
+// checkRecord returns what is wrong with rec under schema, or "".
+func checkRecord(schema []Column, rec []string) string {
+	if len(rec) != len(schema) {
+		return fmt.Sprintf("%d fields, want %d", len(rec), len(schema))
+	}
+	for i, col := range schema {
+		v := strings.TrimSpace(rec[i])
+		if v == "" {
+			if col.Required {
+				return fmt.Sprintf("column %q: value required", col.Name)
+			}
+			continue
+		}
+		if !col.Type.valid(v) {
+			return fmt.Sprintf("column %q: %q is not %s", col.Name, rec[i], col.Type)
+		}
+	}
+	return ""
+}
+
+// valid reports whether v parses as type t.
+func (t ColumnType) valid(v string) bool {
+	var err error
+	switch t {
+	case ColumnInt:
+		_, err = strconv.ParseInt(v, 10, 64)
+	case ColumnFloat:
+		_, err = strconv.ParseFloat(v, 64)
+	case ColumnBool:
+		_, err = strconv.ParseBool(strings.ToLower(v))
+	case ColumnDate:
+		for _, layout := range sniffDateLayouts {
+			if _, err = time.Parse(layout, v); err == nil {
+				break
+			}
+		}
+	}
+	return err == nil
+}
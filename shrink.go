@@ -0,0 +1,153 @@
+// shrink.go
+
+package tables
+
+import (
+	"cmp"
+	"slices"
+)
+
+// ShrinkStrategy decides which columns give up width when a table is fitted
+// to a terminal narrower than it, as Live, Pinned and full-screen output and
+// RenderForPaste do. Set one with SetShrinkStrategy.
+type ShrinkStrategy interface {
+	// Shrink returns the column widths to render with, given the natural
+	// widths of the columns, their SetColumnPriority priorities and the
+	// width available to their contents, which is less than the sum of
+	// widths. Columns shouldn't go below floor unless they already are;
+	// columns that still don't fit are truncated. Shrink may modify and
+	// return widths.
+	Shrink(widths, priority []int, avail, floor int) []int
+}
+
+// ShrinkFunc adapts a function to a ShrinkStrategy.
+type ShrinkFunc func(widths, priority []int, avail, floor int) []int
+
+// Shrink calls f.
+func (f ShrinkFunc) Shrink(widths, priority []int, avail, floor int) []int {
+	return f(widths, priority, avail, floor)
+}
+
+var (
+	// ShrinkLongest narrows the widest column one cell at a time, so short
+	// columns stay intact and long ones even out. It is the default.
+	ShrinkLongest ShrinkStrategy = ShrinkFunc(shrinkLongest)
+
+	// ShrinkProportional narrows every column in proportion to how far it is
+	// above the floor, keeping the relative widths of the columns.
+	ShrinkProportional ShrinkStrategy = ShrinkFunc(shrinkProportional)
+
+	// ShrinkByPriority narrows the columns of lowest SetColumnPriority first,
+	// the rightmost first among equal ones, each down to the floor before the
+	// next is touched, so important columns keep their full width as long
+	// as possible.
+	ShrinkByPriority ShrinkStrategy = ShrinkFunc(shrinkByPriority)
+)
+
+// SetShrinkStrategy sets how columns are narrowed when the table is fitted
+// to a narrower terminal; nil restores ShrinkLongest. Columns that would go
+// below SetMinColumnWidth are left out before any strategy is asked.
+//
+// Example:
+//
+//	t.SetColumnPriority(0, 10).SetShrinkStrategy(tables.ShrinkByPriority)
+func (t *Table) SetShrinkStrategy(s ShrinkStrategy) *Table {
+	t.shrink = s
+	return t
+}
+
+// fitColumns returns widths narrowed by the shrink strategy so the rendered
+// table, borders and padding included, is at most fitWidth cells wide.
+// widths is returned unchanged if it already fits.
+func (t *Table) fitColumns(widths []int) []int {
+	avail := t.fitWidth - (3*len(widths) + 1) // "│ " before each column, " " after, closing "│"
+	sum := 0
+	for _, w := range widths {
+		sum += w
+	}
+	if sum <= avail {
+		return widths
+	}
+
+	s := t.shrink
+	if s == nil {
+		s = ShrinkLongest
+	}
+	priority := make([]int, len(widths))
+	for col := range priority {
+		priority[col] = t.priority[col]
+	}
+	fitted := s.Shrink(append([]int(nil), widths...), priority, avail, max(t.minWidth, 1))
+	if len(fitted) != len(widths) {
+		return shrinkLongest(append([]int(nil), widths...), priority, avail, max(t.minWidth, 1))
+	}
+	for i, w := range fitted {
+		fitted[i] = min(max(w, 1), widths[i]) // never wider than needed
+	}
+	return fitted
+}
+
+func shrinkLongest(widths, _ []int, avail, floor int) []int {
+	sum := 0
+	for _, w := range widths {
+		sum += w
+	}
+	for ; sum > avail; sum-- {
+		widest := 0
+		for i, w := range widths {
+			if w > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] <= floor {
+			break
+		}
+		widths[widest]--
+	}
+	return widths
+}
+
+func shrinkProportional(widths, _ []int, avail, floor int) []int {
+	sum, spare := 0, 0 // spare: width above the floor
+	for _, w := range widths {
+		sum += w
+		spare += max(w-floor, 0)
+	}
+	excess := min(sum-avail, spare)
+	if excess <= 0 {
+		return widths
+	}
+
+	for i, w := range widths {
+		widths[i] -= max(w-floor, 0) * excess / spare
+	}
+	// Rounding down leaves a few cells; take them from the widest columns.
+	return shrinkLongest(widths, nil, sum-excess, floor)
+}
+
+func shrinkByPriority(widths, priority []int, avail, floor int) []int {
+	order := make([]int, len(widths))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		if n := cmp.Compare(priority[a], priority[b]); n != 0 {
+			return n
+		}
+		return cmp.Compare(b, a) // rightmost first
+	})
+
+	excess := -avail
+	for _, w := range widths {
+		excess += w
+	}
+	for _, col := range order {
+		if excess <= 0 {
+			break
+		}
+		c := min(max(widths[col]-floor, 0), excess)
+		widths[col] -= c
+		excess -= c
+	}
+	return widths
+}
@@ -0,0 +1,268 @@
+// sniff.go
+
+package tables
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVSniffTypes looks at every column after import and formats the ones that
+// hold numbers or dates, so imported data reads well without manual setup:
+//
+//   - Integer columns are right-aligned.
+//   - Decimal columns are right-aligned and padded with trailing zeros to the
+//     same number of decimal places, so the decimal points line up.
+//   - Date and time columns, where every value has the same layout (ISO 8601,
+//     RFC 3339, 2006/01/02, 01/02/2006, 02.01.2006, ...), are right-aligned.
+//
+// A column counts as numeric only if all its values are: an optional sign and
+// currency symbol, digits with optional thousands separators, an optional
+// fraction and an optional % sign. Values with a leading zero such as ZIP
+// codes or IDs ("007") keep a column textual. Empty cells and the usual
+// placeholders for missing data ("-", "NA", "N/A", "null") are ignored.
+func CSVSniffTypes() CSVOption {
+	return func(c *csvConfig) { c.sniffTypes = true }
+}
+
+// sniffTypes applies CSVSniffTypes to every column of t.
+func (t *Table) sniffTypes() {
+	for col := range t.headers {
+		var values []string
+		for i, row := range t.rows {
+			if t.rowKinds[i] == rowData && !isMissingValue(string(row[col])) {
+				values = append(values, string(row[col]))
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		switch {
+		case allNumbers(values):
+			t.aligns[col] = AlignRight
+			t.padDecimals(col, maxDecimals(values))
+		case dateLayout(values) != "":
+			t.aligns[col] = AlignRight
+		}
+	}
+}
+
+// padDecimals pads every number in column col with zeros to places decimal
+// places. Integers get a decimal point only when places > 0.
+func (t *Table) padDecimals(col, places int) {
+	if places == 0 {
+		return
+	}
+	for i, row := range t.rows {
+		if t.rowKinds[i] != rowData || isMissingValue(string(row[col])) {
+			continue
+		}
+		num, pct := strings.CutSuffix(string(row[col]), "%")
+		_, frac, hasPoint := strings.Cut(num, ".")
+		if !hasPoint {
+			num += "."
+		}
+		num += strings.Repeat("0", places-len(frac))
+		if pct {
+			num += "%"
+		}
+		row[col] = []byte(num)
+	}
+}
+
+// isMissingValue reports whether s is empty or a common placeholder for a
+// missing value.
+func isMissingValue(s string) bool {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "-", "na", "n/a", "null", "none", "nan":
+		return true
+	}
+	return false
+}
+
+// allNumbers reports whether every value is a number in the sense of
+// CSVSniffTypes.
+func allNumbers(values []string) bool {
+	for _, v := range values {
+		if !isNumber(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// isNumber reports whether s looks like "-$1,234.50", "42", "3.5%" and the
+// like, without a leading zero in the integer part.
+func isNumber(s string) bool {
+	s = strings.TrimSuffix(s, "%")
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		s = s[1:]
+	}
+	for _, sym := range []string{"$", "€", "£", "¥"} {
+		if rest, ok := strings.CutPrefix(s, sym); ok {
+			s = rest
+			break
+		}
+	}
+
+	intPart, frac, hasPoint := strings.Cut(s, ".")
+	if hasPoint && (frac == "" || !isDigits(frac)) {
+		return false
+	}
+	if intPart == "" || (len(intPart) > 1 && intPart[0] == '0') {
+		return false
+	}
+	if !strings.Contains(intPart, ",") {
+		return isDigits(intPart)
+	}
+
+	// Thousands separators: 1-3 digits, then groups of exactly three.
+	groups := strings.Split(intPart, ",")
+	if len(groups[0]) > 3 || !isDigits(groups[0]) {
+		return false
+	}
+	for _, g := range groups[1:] {
+		if len(g) != 3 || !isDigits(g) {
+			return false
+		}
+	}
+	return true
+}
+
+// isDigits reports whether s is a non-empty run of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// maxDecimals returns the largest number of decimal places among values.
+func maxDecimals(values []string) int {
+	places := 0
+	for _, v := range values {
+		if _, frac, ok := strings.Cut(strings.TrimSuffix(v, "%"), "."); ok {
+			places = max(places, len(frac))
+		}
+	}
+	return places
+}
+
+// sniffDateLayouts are the date and time layouts CSVSniffTypes recognizes.
+var sniffDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02",
+	"01/02/2006",
+	"02.01.2006",
+	"02 Jan 2006",
+	"Jan 2, 2006",
+	"15:04:05",
+}
+
+// dateLayout returns the layout every value parses with, or "" if there is
+// none.
+func dateLayout(values []string) string {
+next:
+	for _, layout := range sniffDateLayouts {
+		for _, v := range values {
+			if _, err := time.Parse(layout, v); err != nil {
+				continue next
+			}
+		}
+		return layout
+	}
+	return ""
+}
+
+// sniffHeaderRows is how many records after the first CSVDetectHeader
+// compares it against.
+const sniffHeaderRows = 20
+
+// CSVDetectHeader, when on, decides whether the first record is a header
+// instead of assuming it is. If it isn't, the headers are named "Col 1" to
+// "Col N" and the first record is kept as data. It has no effect together
+// with CSVHeaders or CSVSkipHeader.
+//
+// The test is the one Python's csv.Sniffer.has_header uses. Each column of
+// the next 20 records is classified as integer, float, or text of a fixed
+// length; columns that don't stay in one class are ignored. The first record
+// then gets a vote per column: for a header when its value doesn't fit the
+// class (text in a numeric column, or a different length), against one when
+// it does. A positive total means a header.
+func CSVDetectHeader(on bool) CSVOption {
+	return func(c *csvConfig) { c.detectHeader = on }
+}
+
+// Kinds of column values for looksLikeHeader; lengths of fixed-length text
+// are counted from 0 up.
+const (
+	kindInt   = -1
+	kindFloat = -2
+	kindMixed = -3
+)
+
+// looksLikeHeader reports whether records[0] is a header for the records
+// after it, as described at CSVDetectHeader.
+func looksLikeHeader(records [][]string) bool {
+	header := records[0]
+	kinds := make(map[int]int, len(header))
+	for _, rec := range records[1:min(len(records), sniffHeaderRows+1)] {
+		if len(rec) != len(header) {
+			continue
+		}
+		for col, v := range rec {
+			k := valueKind(v)
+			if prev, seen := kinds[col]; !seen {
+				kinds[col] = k
+			} else if prev != k {
+				kinds[col] = kindMixed
+			}
+		}
+	}
+
+	votes := 0
+	for col, k := range kinds {
+		var fits bool
+		switch k {
+		case kindMixed:
+			continue
+		case kindInt:
+			_, err := strconv.Atoi(strings.TrimSpace(header[col]))
+			fits = err == nil
+		case kindFloat:
+			_, err := strconv.ParseFloat(strings.TrimSpace(header[col]), 64)
+			fits = err == nil
+		default:
+			fits = len([]rune(header[col])) == k
+		}
+		if fits {
+			votes--
+		} else {
+			votes++
+		}
+	}
+	return votes > 0
+}
+
+// valueKind classifies v as kindInt, kindFloat, or text of its length.
+func valueKind(v string) int {
+	trimmed := strings.TrimSpace(v)
+	if _, err := strconv.Atoi(trimmed); err == nil {
+		return kindInt
+	}
+	if _, err := strconv.ParseFloat(trimmed, 64); err == nil {
+		return kindFloat
+	}
+	return len([]rune(v))
+}
@@ -0,0 +1,123 @@
+// sort.go
+
+package tables
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// sortKey is one column SortBy orders the rows by.
+type sortKey struct {
+	col       int
+	ascending bool
+}
+
+// SortBy sorts the table's data rows by column col and adds the column to
+// the sort keys: each call sorts by the keys of all SortBy calls so far, in
+// call order, so later columns only break ties between earlier ones. Calling
+// it again for a column that is already a key changes its direction and
+// sorts again, which also brings rows added since into order.
+//
+// Each column is compared numerically if all its non-empty values are
+// numbers, and as text otherwise; ANSI sequences are ignored. Equal rows keep
+// their order. As with SortByColumn, separators are removed.
+//
+// Example:
+//
+//	t.SortBy(2, false). // highest score first,
+//	    SortBy(0, true) // then by name
+func (t *Table) SortBy(col int, ascending bool) *Table {
+	t.thaw()
+	if !t.checkColumn(col) {
+		return t
+	}
+	i := slices.IndexFunc(t.sortKeys, func(k sortKey) bool { return k.col == col })
+	if i >= 0 {
+		t.sortKeys[i].ascending = ascending
+	} else {
+		t.sortKeys = append(t.sortKeys, sortKey{col, ascending})
+	}
+
+	perm := make([]int, 0, len(t.rows))
+	for pos, kind := range t.rowKinds {
+		if kind == rowData {
+			perm = append(perm, pos)
+		}
+	}
+
+	type column struct {
+		text      []string
+		num       []float64
+		numeric   bool
+		ascending bool
+	}
+	cols := make([]column, len(t.sortKeys))
+	for k, key := range t.sortKeys {
+		c := &cols[k]
+		c.ascending = key.ascending
+		c.numeric = isNumericColumn(t.rows, key.col)
+		c.text = make([]string, len(t.rows))
+		if c.numeric {
+			c.num = make([]float64, len(t.rows))
+		}
+		for _, pos := range perm {
+			c.text[pos] = cellString(t.rows[pos], key.col)
+			if c.numeric {
+				c.num[pos] = parseFloat(c.text[pos])
+			}
+		}
+	}
+
+	slices.SortStableFunc(perm, func(a, b int) int {
+		for _, c := range cols {
+			var n int
+			if c.numeric {
+				n = cmp.Compare(c.num[a], c.num[b])
+			} else {
+				n = strings.Compare(c.text[a], c.text[b])
+			}
+			if n != 0 {
+				if !c.ascending {
+					n = -n
+				}
+				return n
+			}
+		}
+		return 0
+	})
+	t.permuteRows(perm)
+	return t
+}
+
+// SortFunc sorts the table's data rows with less, which reports whether
+// rowA belongs before rowB, for orders the column comparison of SortBy can't
+// express, such as severity ranks or parsed timestamps. The rows are the
+// stored cells, one per column, ANSI sequences included; less must not
+// modify them. The sort is stable and, as with SortByColumn, separators are
+// removed. Keys from earlier SortBy calls are dropped, so a later SortBy
+// starts over from this order.
+//
+// Example:
+//
+//	rank := map[string]int{"critical": 0, "error": 1, "warning": 2}
+//	t.SortFunc(func(a, b [][]byte) bool {
+//	    return rank[string(a[1])] < rank[string(b[1])]
+//	})
+func (t *Table) SortFunc(less func(rowA, rowB [][]byte) bool) *Table {
+	t.thaw()
+	t.sortKeys = nil
+	perm := make([]int, 0, len(t.rows))
+	for pos, kind := range t.rowKinds {
+		if kind == rowData {
+			perm = append(perm, pos)
+		}
+	}
+	sort.SliceStable(perm, func(i, j int) bool {
+		return less(t.rows[perm[i]], t.rows[perm[j]])
+	})
+	t.permuteRows(perm)
+	return t
+}
@@ -0,0 +1,78 @@
+// spacer.go
+
+package tables
+
+import (
+	"bytes"
+)
+
+// spacer is an empty column inserted when the table is rendered.
+type spacer struct {
+	col   int // Column it is drawn before; the number of columns for after the last
+	width int
+}
+
+// AddSpacerColumn draws an empty column width cells wide before column col,
+// as a gutter between groups of related columns. Pass the number of columns
+// to put it after the last one. The gutter has no header text and no data,
+// and it is not part of the table: column indices, Rows and the exporters
+// ignore it, so adding one doesn't shift any other setting. Spanned cells
+// stretch across the gutters inside them. Several gutters at the same place
+// are drawn side by side, in the order they were added.
+//
+// An out-of-range column records ErrColumnOutOfRange, as for the other
+// column settings.
+//
+// Example:
+//
+//	t := tables.NewFromStrings("Host", "CPU", "Mem", "Rx", "Tx").
+//	    AddSpacerColumn(1, 2). // host | resources
+//	    AddSpacerColumn(3, 2)  // resources | network
+//
+//	│ Host  │    │ CPU │ Mem │    │ Rx  │ Tx  │
+func (t *Table) AddSpacerColumn(col, width int) *Table {
+	if col != len(t.headers) && !t.checkColumn(col) {
+		return t
+	}
+	t.spacers = append(t.spacers, spacer{col: col, width: max(width, 0)})
+	return t
+}
+
+// spaced returns t itself, or with AddSpacerColumn a copy with the gutters
+// inserted.
+func (t *Table) spaced() *Table {
+	if t.spacers == nil {
+		return t
+	}
+
+	var from, widths []int // widths of the gutters, in from order
+	for col := 0; col <= len(t.headers); col++ {
+		for _, s := range t.spacers {
+			if s.col == col {
+				from = append(from, -1)
+				widths = append(widths, s.width)
+			}
+		}
+		if col < len(t.headers) {
+			from = append(from, col)
+		}
+	}
+	cp := t.remap(from)
+	cp.spacers = nil
+
+	g := 0
+	for k, col := range from {
+		if col >= 0 {
+			continue
+		}
+		// A blank header measures as wide as the gutter, and the cap keeps
+		// it from growing with the cells below.
+		cp.headers[k] = bytes.Repeat([]byte{' '}, widths[g])
+		cp.maxWidths[k] = widths[g]
+		if cp.locked != nil {
+			cp.locked[k] = widths[g]
+		}
+		g++
+	}
+	return cp
+}
@@ -0,0 +1,336 @@
+// span.go
+
+package tables
+
+import (
+	"bytes"
+)
+
+// Cell is one cell of a row added with AddSpannedRow.
+type Cell struct {
+	Text    string
+	Span    int    // Columns the cell covers; 0 counts as 1
+	RowSpan int    // Data rows the cell covers, its own included; 0 counts as 1
+	Color   *Color // Color of the cell, as set with SetCellColor (nil = none)
+}
+
+// AddSpannedRow adds a data row whose cells may cover several columns, for
+// section banners and grouped summaries. Cells fill the columns from the
+// left; a cell that would run past the last column is cut off there, and
+// columns left over at the end stay empty. The border lines above and below
+// the row get junctions only where its cells actually meet.
+//
+// A spanned cell takes the alignment and color of its first column, and the
+// columns it covers are widened if its text doesn't fit across them. In the
+// stored row the text sits in the first column and the covered ones are
+// empty, so Rows, sorting and the exporters without a notion of spans (CSV,
+// Markdown, ...) see it that way; HTML writes a colspan. UpdateRow turns the
+// row back into a plain one.
+//
+// A cell with a RowSpan also covers its columns in the data rows added after
+// it, for a group label next to its members. Whatever those rows hold in the
+// covered columns is hidden, and separators between them leave the cell open,
+// as with SetMergeRepeated. Row spans only affect the text output.
+//
+// Example:
+//
+//	t := tables.NewFromStrings("Region", "Product", "Units").
+//	    AddSpannedRow(tables.Cell{Text: "Q3 2024", Span: 3}).
+//	    AddRow("EU", "Widget", 12).
+//	    AddRow("US", "Widget", 30).
+//	    AddSpannedRow(tables.Cell{Text: "Summary", Span: 2}, tables.Cell{Text: "42"})
+//
+//	t.AddSpannedRow(tables.Cell{Text: "EU", RowSpan: 2}, tables.Cell{Text: "Widget"}).
+//	    AddRow("", "Gadget")
+func (t *Table) AddSpannedRow(cells ...Cell) *Table {
+	if len(cells) == 0 {
+		return t
+	}
+
+	n := 0
+	for _, c := range cells {
+		n += max(c.Span, 1)
+	}
+	t.checkRowLength(n)
+
+	row := make([][]byte, len(t.headers))
+	spans := make([]int, len(t.headers))
+	var rowSpans []int
+	var colors []*Color
+	col := 0
+	for _, c := range cells {
+		if col >= len(row) {
+			break
+		}
+		if c.Color != nil {
+			if colors == nil {
+				colors = make([]*Color, len(row))
+			}
+			colors[col] = c.Color
+		}
+		row[col] = []byte(c.Text)
+		spans[col] = min(max(c.Span, 1), len(row)-col)
+		if c.RowSpan > 1 {
+			if rowSpans == nil {
+				rowSpans = make([]int, len(row))
+			}
+			rowSpans[col] = c.RowSpan
+		}
+		col += spans[col]
+	}
+	for ; col < len(row); col++ {
+		spans[col] = 1
+	}
+	for i := range row {
+		if row[i] == nil {
+			row[i] = []byte{}
+		}
+	}
+
+	t.appendRow(row)
+	pos := len(t.rows) - 1
+	if colors != nil {
+		idx := t.dataRowsFrom(0) - 1
+		for col, c := range colors {
+			if c != nil {
+				t.SetCellColor(idx, col, c)
+			}
+		}
+	}
+	for _, span := range spans {
+		if span != 1 {
+			t.setSpans(pos, spans)
+			break
+		}
+	}
+	if rowSpans != nil {
+		t.rowDown = growTo(t.rowDown, pos+1)
+		t.rowDown[pos] = rowSpans
+	}
+	return t
+}
+
+// AddSection adds a banner row spanning the full width of the table, for
+// grouped reports that label each group of rows. A separator is drawn above
+// the banner (unless it comes right after the header or another separator)
+// and below it, with junctions only where the columns meet:
+//
+//	├──────────────────────┤
+//	│ Region: EU           │
+//	├────────┬─────────────┤
+//	│ Widget │ 12          │
+//
+// The banner is a data row like any other added with AddSpannedRow, so
+// sorting the table mixes it in with the rest, but SetAutoIndex doesn't number
+// it. Its color is the one set with SetSectionColor when it is added.
+//
+// Example:
+//
+//	t.AddSection("Region: EU").AddRow("Widget", 12).AddRow("Gadget", 8).
+//	    AddSection("Region: US").AddRow("Widget", 30)
+func (t *Table) AddSection(title string) *Table {
+	if n := len(t.rows); n > 0 && t.rowKinds[n-1] != rowSeparator {
+		t.AddSeparator()
+	}
+	t.AddSpannedRow(Cell{Text: title, Span: len(t.headers), Color: t.sectionColor})
+	t.setAux(len(t.rows) - 1)
+	return t.AddSeparator()
+}
+
+// SetSectionColor sets the color of the banners added with AddSection after
+// the call. Pass nil for none.
+func (t *Table) SetSectionColor(c *Color) *Table {
+	t.sectionColor = c
+	return t
+}
+
+// setSpans records the column spans of the row at pos. rowSpans is only as
+// long as it needs to be, so plain tables never allocate it.
+func (t *Table) setSpans(pos int, spans []int) {
+	if pos >= len(t.rowSpans) {
+		if spans == nil {
+			return
+		}
+		t.rowSpans = growTo(t.rowSpans, pos+1)
+	}
+	t.rowSpans[pos] = spans
+}
+
+// growTo returns s extended with zero entries to length n.
+func growTo[T any](s []T, n int) []T {
+	if len(s) >= n {
+		return s
+	}
+	return append(s, make([]T, n-len(s))...)
+}
+
+// spansAt returns the column spans of the row at pos: for each column the
+// number of columns the cell starting there covers, 0 for columns covered
+// by a cell to their left. It is nil for plain rows, separators, and
+// positions outside the table.
+func (t *Table) spansAt(pos int) []int {
+	if pos < 0 || pos >= len(t.rowSpans) {
+		return nil
+	}
+	return t.rowSpans[pos]
+}
+
+// setAux marks the row at pos as added by the library rather than the
+// caller, like a section banner.
+func (t *Table) setAux(pos int) {
+	t.rowAux = growTo(t.rowAux, pos+1)
+	t.rowAux[pos] = true
+}
+
+// isAux reports whether the row at pos was marked with setAux.
+func (t *Table) isAux(pos int) bool {
+	return pos >= 0 && pos < len(t.rowAux) && t.rowAux[pos]
+}
+
+// spanWidth returns the width available to text spanning all of widths,
+// from the first column's content to the last one's: the columns plus the
+// " │ " between each pair.
+func spanWidth(widths []int) int {
+	w := 3 * (len(widths) - 1)
+	for _, cw := range widths {
+		w += cw
+	}
+	return w
+}
+
+// widenSpan grows widths[from:to] in place until their span is at least
+// need cells wide. The extra is spread evenly, any remainder going to the
+// last columns, and no column grows past its max width.
+func (t *Table) widenSpan(widths []int, from, to, need int) {
+	extra := need - spanWidth(widths[from:to])
+	for extra > 0 {
+		var open []int
+		for i := from; i < to; i++ {
+			if t.maxWidths[i] == 0 || widths[i] < t.maxWidths[i] {
+				open = append(open, i)
+			}
+		}
+		if len(open) == 0 {
+			return
+		}
+		share, rest := extra/len(open), extra%len(open)
+		for k, i := range open {
+			add := share
+			if k >= len(open)-rest {
+				add++
+			}
+			if m := t.maxWidths[i]; m > 0 {
+				add = min(add, m-widths[i])
+			}
+			widths[i] += add
+			extra -= add
+		}
+	}
+}
+
+// widenForSpans widens the columns under every spanned cell whose text
+// doesn't fit across them.
+func (t *Table) widenForSpans(widths []int) {
+	for pos, spans := range t.rowSpans {
+		if spans == nil {
+			continue
+		}
+		for col, span := range spans {
+			if span > 1 {
+				cell := t.rows[pos][col]
+				t.widenSpan(widths, col, col+span, t.cellWidth(cell, t.rowASCII[pos]))
+			}
+		}
+	}
+}
+
+// renderJoinedBorder renders a border line between a row with spans above
+// and one with spans below (nil for plain rows), with junctions only where a
+// column boundary continues on either side. Columns marked in through are
+// left open, for a merged cell that continues past the line (see
+// SetMergeRepeated).
+func (t *Table) renderJoinedBorder(buf *bytes.Buffer, widths []int, borderType string, above, below []int, through []bool) {
+	if above == nil && below == nil && through == nil {
+		t.renderBorder(buf, widths, borderType)
+		return
+	}
+	if len(widths) == 0 {
+		return
+	}
+
+	s := t.style
+	open := func(col int) bool { return through != nil && through[col] }
+	mark := buf.Len()
+	defer t.colorBorder(buf, mark)
+	vertical := borderType != "top" && borderType != "bottom" // at the outer edges
+
+	buf.WriteRune(s.junction(vertical || borderType == "bottom", vertical || borderType == "top", false, !open(0)))
+	for i, width := range widths {
+		fill := s.Horizontal
+		if open(i) {
+			fill = ' '
+		}
+		for range width + 2 {
+			buf.WriteRune(fill)
+		}
+		if i == len(widths)-1 {
+			break
+		}
+
+		// Is there a boundary between columns i and i+1 above and below?
+		up := borderType != "top" && borderType != "title" && (above == nil || above[i+1] != 0)
+		down := borderType != "bottom" && (below == nil || below[i+1] != 0)
+		buf.WriteRune(s.junction(up, down, !open(i), !open(i+1)))
+	}
+	buf.WriteRune(s.junction(vertical || borderType == "bottom", vertical || borderType == "top", !open(len(widths)-1), false))
+	buf.WriteByte('\n')
+}
+
+// junction returns the border rune joining lines going up, down, left and
+// right from a point.
+func (s Style) junction(up, down, left, right bool) rune {
+	switch {
+	case up && down && left && right:
+		return s.Cross
+	case up && down && right:
+		return s.LeftTee
+	case up && down && left:
+		return s.RightTee
+	case up && left && right:
+		return s.BottomTee
+	case down && left && right:
+		return s.TopTee
+	case up && down:
+		return s.Vertical
+	case left || right:
+		switch {
+		case down && right:
+			return s.TopLeft
+		case down && left:
+			return s.TopRight
+		case up && right:
+			return s.BottomLeft
+		case up && left:
+			return s.BottomRight
+		}
+		return s.Horizontal
+	case up || down:
+		return s.Vertical
+	}
+	return ' '
+}
+
+// permuteSpans reorders s, which is kept parallel to rows but may be
+// shorter, like permuteRows does. nil stays nil.
+func permuteSpans[T any](s []T, perm []int) []T {
+	if s == nil {
+		return nil
+	}
+	out := make([]T, len(perm))
+	for i, p := range perm {
+		if p < len(s) {
+			out[i] = s[p]
+		}
+	}
+	return out
+}
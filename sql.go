@@ -0,0 +1,180 @@
+// sql.go
+
+package tables
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SQLRows is the subset of *sql.Rows used by NewFromRows. Accepting an
+// interface keeps database/sql out of the import graph of programs that never
+// touch a database, and lets wrappers such as sqlx.Rows be passed directly.
+type SQLRows interface {
+	Columns() ([]string, error)
+	Next() bool
+	Scan(dest ...any) error
+	Err() error
+	Close() error
+}
+
+// sqlConfig holds the settings applied by SQLOption values.
+type sqlConfig struct {
+	null []byte
+}
+
+// SQLOption configures NewFromRows.
+type SQLOption func(*sqlConfig)
+
+// SQLNullText sets the text shown for NULL values (default "NULL").
+func SQLNullText(s string) SQLOption {
+	return func(c *sqlConfig) { c.null = []byte(s) }
+}
+
+// NewFromRows builds a table from a query result: column names become the
+// headers and every row is scanned into the table as raw bytes, so no value
+// is converted through an intermediate Go type. NULL values are shown as
+// "NULL" unless SQLNullText says otherwise.
+//
+// rows is read to the end and closed.
+//
+// Example:
+//
+//	rows, err := db.Query("SELECT id, name, email FROM users")
+//	if err != nil {
+//	    return err
+//	}
+//	t, err := tables.NewFromRows(rows, tables.SQLNullText("-"))
+//	if err != nil {
+//	    return err
+//	}
+//	t.Print()
+func NewFromRows(rows SQLRows, opts ...SQLOption) (*Table, error) {
+	defer rows.Close()
+
+	cfg := sqlConfig{null: []byte("NULL")}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("tables: reading SQL columns: %w", err)
+	}
+	t := NewFromStrings(cols...)
+
+	values := make([][]byte, len(cols))
+	dest := make([]any, len(cols))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("tables: scanning SQL row: %w", err)
+		}
+		for i, v := range values {
+			if v == nil {
+				values[i] = cfg.null
+			}
+		}
+		t.AddRowBytes(values...)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("tables: reading SQL rows: %w", err)
+	}
+	return t, nil
+}
+
+// SQLInserts returns one INSERT statement per data row, ready to use as a
+// seed script:
+//
+//	INSERT INTO "users" ("id", "name") VALUES (1, 'O''Brien');
+//
+// Headers become the column list. Identifiers are double-quoted and values
+// single-quoted, with embedded quotes doubled, so no cell content can end a
+// literal early. A dotted tableName such as "public.users" is quoted part by
+// part. Cells that are plain decimal numbers are written unquoted; anything
+// else, including numbers with leading zeros such as ZIP codes, is a string.
+//
+// ANSI sequences are stripped. Separator rows and the footer are left out,
+// since they are presentation, not data.
+//
+// The quoting is standard SQL, accepted by PostgreSQL, SQLite and SQL Server.
+// MySQL needs the ANSI_QUOTES and NO_BACKSLASH_ESCAPES modes to read it the
+// same way.
+func (t *Table) SQLInserts(tableName string) string {
+	t = t.warm()
+	if len(t.headers) == 0 {
+		return ""
+	}
+
+	var prefix strings.Builder
+	prefix.WriteString("INSERT INTO ")
+	for i, part := range strings.Split(tableName, ".") {
+		if i > 0 {
+			prefix.WriteByte('.')
+		}
+		prefix.WriteString(sqlIdent(part))
+	}
+	prefix.WriteString(" (")
+	for i, h := range t.headers {
+		if i > 0 {
+			prefix.WriteString(", ")
+		}
+		prefix.WriteString(sqlIdent(StripANSI(string(h))))
+	}
+	prefix.WriteString(") VALUES (")
+
+	var sb strings.Builder
+	for i, row := range t.rows {
+		if t.rowKinds[i] == rowSeparator {
+			continue
+		}
+		sb.WriteString(prefix.String())
+		for j := range t.headers {
+			if j > 0 {
+				sb.WriteString(", ")
+			}
+			var cell string
+			if j < len(row) {
+				cell = StripANSI(string(row[j]))
+			}
+			sb.WriteString(sqlValue(cell))
+		}
+		sb.WriteString(");\n")
+	}
+	return sb.String()
+}
+
+// sqlIdent quotes an SQL identifier.
+func sqlIdent(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// sqlValue returns s as an SQL literal: unquoted if it is a plain decimal
+// number, otherwise a quoted string.
+func sqlValue(s string) string {
+	if isSQLNumber(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// isSQLNumber reports whether s is an optionally negative decimal number
+// without leading zeros ("0", "-12", "3.50"), which reads back unchanged.
+func isSQLNumber(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+	intPart, frac, hasFrac := strings.Cut(s, ".")
+	if intPart == "" || (len(intPart) > 1 && intPart[0] == '0') || (hasFrac && frac == "") {
+		return false
+	}
+	for _, part := range []string{intPart, frac} {
+		for i := 0; i < len(part); i++ {
+			if part[i] < '0' || part[i] > '9' {
+				return false
+			}
+		}
+	}
+	return true
+}
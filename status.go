@@ -0,0 +1,90 @@
+// status.go
+
+package tables
+
+// Status is the semantic level of a row, such as the result of a health
+// check, which the table's ColorScheme turns into a color.
+type Status int
+
+const (
+	StatusInfo    Status = iota // Neutral information
+	StatusSuccess               // A check passed
+	StatusWarning               // Needs attention, but works
+	StatusError                 // A check failed
+	StatusMuted                 // Of no interest, such as a skipped check
+)
+
+// ColorScheme holds the color of each Status, so that tools built on the
+// library can show statuses the same way: an organization defines its scheme
+// once, in a shared package, and every tool passes it to SetColorScheme. A
+// nil color leaves rows of that status uncolored.
+//
+// Example:
+//
+//	var Palette = tables.ColorScheme{
+//	    Success: tables.NewColor().WithFg(tables.Color256(34)),
+//	    Warning: tables.NewColor().WithFg(tables.Color256(214)),
+//	    Error:   tables.NewColor().WithFg(tables.Color256(196)).WithStyle(tables.Bold),
+//	}
+type ColorScheme struct {
+	Info    *Color
+	Success *Color
+	Warning *Color
+	Error   *Color
+	Muted   *Color
+}
+
+// SchemeFromTheme returns the scheme of th's Info, Success, Warning, Error
+// and Muted colors, which tables use until SetColorScheme is called.
+func SchemeFromTheme(th Theme) ColorScheme {
+	return ColorScheme{
+		Info:    themeColor(th.Info),
+		Success: themeColor(th.Success),
+		Warning: themeColor(th.Warning),
+		Error:   themeColor(th.Error),
+		Muted:   themeColor(th.Muted),
+	}
+}
+
+// Color returns the color of level, or nil for none or an unknown level.
+func (s ColorScheme) Color(level Status) *Color {
+	switch level {
+	case StatusInfo:
+		return s.Info
+	case StatusSuccess:
+		return s.Success
+	case StatusWarning:
+		return s.Warning
+	case StatusError:
+		return s.Error
+	case StatusMuted:
+		return s.Muted
+	}
+	return nil
+}
+
+// SetColorScheme sets the colors AddStatusRow gives each Status. Without it
+// they come from the table's theme (see SetTheme), or DefaultTheme.
+func (t *Table) SetColorScheme(s ColorScheme) *Table {
+	t.scheme = &s
+	return t
+}
+
+// AddStatusRow adds a row like AddRow and colors it with the color of level
+// in the table's ColorScheme, as AddRowColored does. The color is the one in
+// effect when the row is added; SetColorScheme doesn't recolor earlier rows.
+//
+// Example:
+//
+//	t.AddStatusRow(tables.StatusError, "db-1", "unreachable")
+func (t *Table) AddStatusRow(level Status, values ...any) *Table {
+	return t.AddRowColored(t.colorScheme().Color(level), values...)
+}
+
+// colorScheme returns the scheme the table colors statuses with.
+func (t *Table) colorScheme() ColorScheme {
+	if t.scheme != nil {
+		return *t.scheme
+	}
+	return SchemeFromTheme(*t.palette())
+}
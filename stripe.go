@@ -0,0 +1,70 @@
+// stripe.go
+
+package tables
+
+import (
+	"strings"
+)
+
+// SetRowStriping colors the rows of the text output alternately with even
+// and odd, counting data rows as drawn from 0, so the eye can follow a row
+// across a wide table. Give the colors a background (WithBg): it fills the
+// whole row, padding and wrapped lines included, and shows behind row, column
+// and cell colors, whose own background wins where they have one. Section
+// banners, group subtotals and other lines the library adds aren't striped
+// and don't count. Pass nil for both to turn striping off.
+//
+// Example:
+//
+//	t.SetRowStriping(nil, tables.NewColor().WithBg(tables.BgColor256(236)))
+func (t *Table) SetRowStriping(even, odd *Color) *Table {
+	if even == nil && odd == nil {
+		t.stripes = nil
+		return t
+	}
+	t.stripes = []*Color{even, odd}
+	return t
+}
+
+// striped returns t itself, or with SetRowStriping a copy with the stripe of
+// each data row worked out for stripeAt.
+func (t *Table) striped() *Table {
+	if t.stripes == nil {
+		return t
+	}
+
+	cp := *t
+	cp.stripes = nil
+	cp.stripeRows = make([]*Color, 0, len(t.rows))
+	n := 0
+	for pos, kind := range t.rowKinds {
+		if kind != rowData {
+			continue
+		}
+		if t.isAux(pos) {
+			cp.stripeRows = append(cp.stripeRows, nil)
+			continue
+		}
+		cp.stripeRows = append(cp.stripeRows, t.stripes[n%2])
+		n++
+	}
+	return &cp
+}
+
+// stripeAt returns the stripe color of data row rowIdx, or nil.
+func (t *Table) stripeAt(rowIdx int) *Color {
+	if rowIdx < 0 || rowIdx >= len(t.stripeRows) {
+		return nil
+	}
+	return t.stripeRows[rowIdx]
+}
+
+// applyStripe wraps a rendered cell, padding included, in the stripe color
+// c, setting it again after every reset inside so the background continues.
+func applyStripe(c *Color, cell string) string {
+	codes := c.codes()
+	if codes == "" {
+		return cell
+	}
+	return codes + strings.ReplaceAll(cell, Reset, Reset+codes) + Reset
+}
@@ -0,0 +1,136 @@
+// structs.go
+
+package tables
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// structField describes one exported struct field that becomes a column.
+type structField struct {
+	index     []int
+	header    string
+	order     int
+	omitEmpty bool
+}
+
+// NewFromStructs builds a table from a slice (or array) of structs or struct
+// pointers. Every exported field becomes a column, in declaration order, and
+// every element becomes a row. The header defaults to the field name. A
+// `table` struct tag shapes the output, using the same layout as encoding/json
+// tags — a name followed by comma-separated options:
+//
+//	type Service struct {
+//	    Name     string
+//	    Uptime   float64 `table:"Uptime %"`      // header name
+//	    Restarts int     `table:",omitempty"`    // zero values render empty
+//	    ID       string  `table:"ID,order=-1"`   // moved to the front
+//	    internal string                          // unexported: never shown
+//	    Secret   string  `table:"-"`             // skipped
+//	}
+//
+//	t, err := tables.NewFromStructs(services)
+//
+// Columns are sorted by their order value, lowest first. Fields without one
+// have order 0, and ties keep declaration order. A tag of "-," names the
+// column "-" rather than skipping it.
+//
+// Fields of embedded structs are promoted just as the Go selector rules
+// promote them. Field values go through the same conversion as AddRow. Nil
+// pointer fields and nil elements render as empty cells. An error is returned if slice is
+// not a slice or array of structs.
+func NewFromStructs(slice interface{}) (*Table, error) {
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("tables: NewFromStructs: expected a slice of structs, got %T", slice)
+	}
+
+	elem := v.Type().Elem()
+	if elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("tables: NewFromStructs: expected a slice of structs, got %T", slice)
+	}
+
+	fields := structFields(elem)
+	headers := make([]string, len(fields))
+	for i, f := range fields {
+		headers[i] = f.header
+	}
+	t := NewFromStrings(headers...)
+
+	values := make([]any, len(fields))
+	for i := 0; i < v.Len(); i++ {
+		item := v.Index(i)
+		if item.Kind() == reflect.Pointer {
+			if item.IsNil() {
+				t.AddRowBytes(make([][]byte, len(fields))...)
+				continue
+			}
+			item = item.Elem()
+		}
+		for j, f := range fields {
+			fv, err := item.FieldByIndexErr(f.index)
+			if err != nil || (f.omitEmpty && fv.IsZero()) {
+				values[j] = "" // promoted through a nil embedded pointer, or omitted
+				continue
+			}
+			values[j] = fieldValue(fv)
+		}
+		t.AddRow(values...)
+	}
+	return t, nil
+}
+
+// structFields lists the exported fields of typ that become columns, in
+// column order.
+func structFields(typ reflect.Type) []structField {
+	var fields []structField
+	for _, f := range reflect.VisibleFields(typ) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		tag := f.Tag.Get("table")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		sf := structField{index: f.Index, header: name}
+		if sf.header == "" {
+			sf.header = f.Name
+		}
+		for _, opt := range strings.Split(opts, ",") {
+			switch {
+			case opt == "omitempty":
+				sf.omitEmpty = true
+			case strings.HasPrefix(opt, "order="):
+				// A malformed value leaves the default order
+				sf.order, _ = strconv.Atoi(strings.TrimPrefix(opt, "order="))
+			}
+		}
+		fields = append(fields, sf)
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].order < fields[j].order
+	})
+	return fields
+}
+
+// fieldValue unwraps pointer fields so AddRow sees the value rather than an
+// address. Nil pointers become an empty string.
+func fieldValue(v reflect.Value) any {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	return v.Interface()
+}
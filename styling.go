@@ -1,5 +1,10 @@
 package tables
 
+import (
+	"bytes"
+	"strings"
+)
+
 // rowcol is a compact composite key for the per-cell color map.
 // Using a struct as a map key is zero-allocation.
 type rowcol struct {
@@ -9,25 +14,20 @@ type rowcol struct {
 // Apply wraps text with the receiver's ANSI codes and returns the result.
 // If DisableColors is set or the Color is nil, the original text is returned.
 func (c *Color) Apply(text string) string {
-	if c == nil || DisableColors {
+	codes := c.codes()
+	if codes == "" {
 		return text
 	}
+	return codes + text + Reset
+}
 
-	codes := make([]string, 0, 1+1+len(c.styles))
-	if c.fg != "" {
-		codes = append(codes, c.fg)
-	}
-
-	if c.bg != "" {
-		codes = append(codes, c.bg)
-	}
-
-	codes = append(codes, c.styles...)
-	if len(codes) == 0 {
-		return text
+// codes returns the ANSI codes Apply writes before the text, or "" if it
+// writes none.
+func (c *Color) codes() string {
+	if c == nil || DisableColors {
+		return ""
 	}
-
-	return Colorize(text, codes...)
+	return c.fg + c.bg + strings.Join(c.styles, "")
 }
 
 // --- Header styling ----------------------------------------------------------
@@ -45,11 +45,61 @@ func (t *Table) SetHeaderColor(c *Color) *Table {
 	return t
 }
 
+// SetHeaderStyle sets the text attributes of the header row, such as Bold and
+// Underline, keeping the foreground and background of the header color. It
+// saves building a Color for the common case of a header that only needs to
+// stand out. Call it without codes to drop the attributes again.
+//
+// Example:
+//
+//	t.SetHeaderStyle(tables.Bold, tables.Underline)
+func (t *Table) SetHeaderStyle(codes ...string) *Table {
+	c := NewColor()
+	if t.headerColor != nil {
+		c.fg, c.bg = t.headerColor.fg, t.headerColor.bg
+	}
+	c.styles = append(c.styles, codes...)
+	t.headerColor = c
+	return t
+}
+
+// SetBorderColor colors the border characters of the table, its rules,
+// corners and column separators, leaving the cells as they are. Column
+// widths don't change, since color codes take no room. Pass nil for plain
+// borders.
+//
+// Example:
+//
+//	t.SetBorderColor(tables.NewColor().WithFg(tables.Color256(240))) // subtle gray
+func (t *Table) SetBorderColor(c *Color) *Table {
+	t.borderColor = c
+	return t
+}
+
+// colorBorder colors the border line written to buf from mark on, keeping
+// its newline outside the color.
+func (t *Table) colorBorder(buf *bytes.Buffer, mark int) {
+	codes := t.borderColor.codes()
+	if codes == "" || buf.Len() == mark {
+		return
+	}
+	line := bytes.TrimSuffix(buf.Bytes()[mark:], []byte{'\n'})
+	colored := make([]byte, 0, len(codes)+len(line)+len(Reset))
+	colored = append(append(append(colored, codes...), line...), Reset...)
+	newline := buf.Len()-mark > len(line)
+	buf.Truncate(mark)
+	buf.Write(colored)
+	if newline {
+		buf.WriteByte('\n')
+	}
+}
+
 // --- Row / column / cell coloring --------------------------------------------
 
 // SetRowColor applies a color to every cell in the given data row (0-indexed,
 // not counting separator rows). If the row index is out of range the call is a
-// no-op, consistent with how SetAlign and SetMaxWidth behave.
+// no-op, consistent with how SetAlign and SetMaxWidth behave. The color stays
+// with the row when the table is sorted.
 func (t *Table) SetRowColor(row int, c *Color) *Table {
 	if row < 0 {
 		return t
@@ -63,9 +113,11 @@ func (t *Table) SetRowColor(row int, c *Color) *Table {
 }
 
 // SetColumnColor applies a color to every data cell in the given column
-// (0-indexed). The header cell is NOT affected — use SetHeaderColor for that.
+// (0-indexed), except cells that already contain ANSI sequences, such as a
+// value colored with Error, which keep their own colors. The header cell is
+// NOT affected — use SetHeaderColor for that.
 func (t *Table) SetColumnColor(col int, c *Color) *Table {
-	if col < 0 || col >= len(t.headers) {
+	if !t.checkColumn(col) {
 		return t
 	}
 
@@ -77,9 +129,10 @@ func (t *Table) SetColumnColor(col int, c *Color) *Table {
 }
 
 // SetCellColor applies a color to a single data cell at (row, col), both
-// 0-indexed. Cell color takes priority over row and column colors.
+// 0-indexed. Cell color takes priority over row and column colors, and stays
+// with the row when the table is sorted.
 func (t *Table) SetCellColor(row, col int, c *Color) *Table {
-	if row < 0 || col < 0 || col >= len(t.headers) {
+	if row < 0 || !t.checkColumn(col) {
 		return t
 	}
 	if t.cellColors == nil {
@@ -89,9 +142,10 @@ func (t *Table) SetCellColor(row, col int, c *Color) *Table {
 	return t
 }
 
-// cellColor resolves the effective color for a data cell, applying the
-// priority: cell > row > column > nil.
-func (t *Table) cellColor(row, col int) *Color {
+// cellColor resolves the effective color for a data cell holding cell,
+// applying the priority: cell > row > column > body > nil. The column and
+// body colors are left off cells that carry ANSI sequences of their own.
+func (t *Table) cellColor(row, col int, cell []byte) *Color {
 	if t.cellColors != nil {
 		if c, ok := t.cellColors[rowcol{row, col}]; ok {
 			return c
@@ -102,11 +156,136 @@ func (t *Table) cellColor(row, col int) *Color {
 			return c
 		}
 	}
+	if bytes.IndexByte(cell, '\033') >= 0 {
+		return nil // colored already
+	}
 	if t.colColors != nil {
 		if c, ok := t.colColors[col]; ok {
 			return c
 		}
 	}
-	return nil
+	return t.bodyColor
+}
+
+// RowStyleAt returns the color of each cell of data row i (0-indexed, not
+// counting separator rows) as the text output applies it: cell color over
+// row color over column color over the SetTheme body color, nil where none
+// is set. Custom backends can use it to honor the table's colors. It returns
+// nil if i is out of range.
+func (t *Table) RowStyleAt(i int) []*Color {
+	if i < 0 || i >= t.dataRowsFrom(0) {
+		return nil
+	}
+	w := t.warm()
+	row := w.rows[w.rowPos(i)]
+	styles := make([]*Color, len(t.headers))
+	for col := range styles {
+		styles[col] = t.cellColor(i, col, cellAt(row, col))
+	}
+	return styles
+}
+
+// moveColors re-keys row and cell colors for permuteRows reordering the rows
+// by perm, so colors stay with their rows rather than their positions.
+// Colors of rows perm leaves out are dropped; colors set ahead for rows not
+// added yet are kept as they are.
+func (t *Table) moveColors(perm []int) {
+	if t.rowColors == nil && t.cellColors == nil {
+		return
+	}
+
+	// Data row index of each position before and after.
+	before := make([]int, len(t.rows))
+	n := 0
+	for pos, kind := range t.rowKinds {
+		if kind == rowData {
+			before[pos] = n
+			n++
+		}
+	}
+	moved := make([]int, n)
+	for i := range moved {
+		moved[i] = -1
+	}
+	next := 0
+	for _, p := range perm {
+		if t.rowKinds[p] == rowData {
+			moved[before[p]] = next
+			next++
+		}
+	}
+	to := func(row int) int {
+		if row >= n {
+			return row
+		}
+		return moved[row]
+	}
+
+	if t.rowColors != nil {
+		colors := make(map[int]*Color, len(t.rowColors))
+		for row, c := range t.rowColors {
+			if r := to(row); r >= 0 {
+				colors[r] = c
+			}
+		}
+		t.rowColors = colors
+	}
+	if t.cellColors != nil {
+		colors := make(map[rowcol]*Color, len(t.cellColors))
+		for key, c := range t.cellColors {
+			if r := to(key.row); r >= 0 {
+				colors[rowcol{r, key.col}] = c
+			}
+		}
+		t.cellColors = colors
+	}
+}
+
+// --- Row height / vertical padding -------------------------------------------
+
+// SetRowHeight sets the minimum number of lines every data row occupies,
+// counting any vertical padding. Rows shorter than n are filled with blank
+// lines below their content. Header and footer rows are not affected. Values
+// below 1 restore the default of one line per row.
+func (t *Table) SetRowHeight(n int) *Table {
+	t.rowHeight = max(n, 0)
+	return t
+}
+
+// SetVerticalPadding adds n blank lines above and below the content of every
+// data row, inside the borders. Combined with SetRowHeight and a generous
+// border style this gives presentation-style tables suitable for screenshots.
+func (t *Table) SetVerticalPadding(n int) *Table {
+	t.vPadding = max(n, 0)
+	return t
 }
 
+// SetRowSeparators, when on, draws a horizontal rule between every two data
+// rows, like the one under the header, so the eye can follow a row across a
+// dense table. Explicit separators added with AddSeparator are not doubled,
+// and merged cells (see SetMergeRepeated) stay open across the rules.
+func (t *Table) SetRowSeparators(on bool) *Table {
+	t.rowRules = on
+	return t
+}
+
+// SetHeaderRepeat draws the header row again, between rules, before every
+// n-th data row after the first, so the column names stay in sight while
+// scrolling through a long table in a terminal or pager. The first values of
+// runs merged by SetMergeRepeated are shown again under a repeated header.
+// Only the text output is affected. n <= 0 draws the header once, at the top.
+func (t *Table) SetHeaderRepeat(n int) *Table {
+	t.reheader = max(n, 0)
+	return t
+}
+
+// rowPadding returns how many blank lines go above and below a row whose
+// content spans lines physical lines. Only data rows (rowIdx >= 0) are padded.
+func (t *Table) rowPadding(rowIdx, lines int) (top, bottom int) {
+	if rowIdx < 0 {
+		return 0, 0
+	}
+	top = t.vPadding
+	bottom = max(t.vPadding, t.rowHeight-lines-t.vPadding)
+	return top, bottom
+}
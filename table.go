@@ -3,10 +3,15 @@ package tables
 
 import (
 	"bytes"
+	"encoding"
 	"fmt"
 	"io"
+	"os"
+	"slices"
 	"strconv"
 	"sync"
+	"text/template"
+	"time"
 )
 
 // Constants
@@ -45,21 +50,111 @@ type Style struct {
 type Table struct {
 	headers   [][]byte   // Column headers as bytes
 	rows      [][][]byte // Each row contains multiple cells, each cell is []byte
-	rowKinds  []rowKind	 // Parallel to rows — rowData or rowSeparator
+	rowKinds  []rowKind  // Parallel to rows — rowData or rowSeparator
+	rowASCII  []bool     // Parallel to rows — every cell is printable ASCII
 	style     Style
 	aligns    []Align   // Alignment per column
 	maxWidths []int     // Max width per column (0 = unlimited)
 	widthFunc WidthFunc // Pluggable width calculation function
+	asciiUnit bool      // widthFunc measures printable ASCII as width 1
+	baseWidth WidthFunc // widthFunc before SetEmojiWidth (nil = widthFunc)
+	emojiWide int       // Width of emoji from SetEmojiWidth (0 = baseWidth's)
+	rowHeight int       // Minimum lines per data row, padding included
+	vPadding  int       // Blank lines above and below each data row
+	rowRules  bool      // Rule between every two data rows, see SetRowSeparators
+	reheader  int       // Data rows between repeats of the header, see SetHeaderRepeat (0 = once)
+	locked    []int     // Frozen column widths from LockWidths (nil = measure every render)
+
+	validators   map[int]func([]byte) error // Checks of the values per column, see SetColumnValidator
+	invalidMark  []byte                     // Shown before values failing their check
+	invalidColor *Color                     // Color of values failing their check (nil = theme's error color)
+	flag         *Color                     // Color validated marks failing cells with, for flagged
 
 	// Styling
 	headerColor *Color
 	rowColors   map[int]*Color
 	colColors   map[int]*Color
 	cellColors  map[rowcol]*Color
+	bodyColor   *Color // Color of data cells without one of their own, see SetTheme
+	theme       *Theme // Palette of the colors the table picks itself, see SetTheme (nil = DefaultTheme)
+	borderColor *Color // Color of the border characters, see SetBorderColor
+
+	colorMode ColorMode    // Whether WriteTo keeps ANSI sequences, see SetColorMode
+	scheme    *ColorScheme // Colors of AddStatusRow's statuses (nil = from the theme)
 
 	footer      [][]byte
 	footerColor *Color
 
+	diff          *diffPair // Expected/actual columns for inline diffs (nil = off)
+	wideEmulation bool      // Replace wide glyphs with ASCII pairs at render time
+	escapePolicy  EscapePolicy
+	autoLink      map[int]bool  // Columns whose URLs and paths become hyperlinks
+	compatAllow   map[rune]bool // Non-ASCII characters RenderCompat may emit
+	compatMode    bool          // Replace disallowed characters (RenderCompat copies only)
+	pasteMode     bool          // Turn tabs into spaces (RenderForPaste copies only)
+	maxOutput     int           // Rendered size cap in bytes (0 = unlimited)
+	err           error         // First error from a chained call, see Err
+	nfc           bool          // NFC-normalize content as it is added
+	dirty         map[int]bool  // Positions in rows changed since the last Live frame
+	dirtyAll      bool          // Rows were reordered since the last Live frame
+	fitWidth      int           // Terminal width Live is rendering for (0 = don't fit)
+	minWidth      int           // Narrowest a fitted column gets, see SetMinColumnWidth (0 = 1)
+	priority      map[int]int   // Which columns fitting leaves out last, see SetColumnPriority
+
+	shrink ShrinkStrategy // How fitting narrows columns (nil = ShrinkLongest)
+
+	rowLink func(row []string) string // URL of each data row in HTML output, see SetRowLink
+
+	title      []byte // Caption above the table (nil = none)
+	titleAlign Align
+	titleBoxed bool // Draw the title inside the top border
+
+	sectionColor *Color // Color of the banners added with AddSection
+
+	stripes    []*Color // Even and odd row colors, see SetRowStriping (nil = off)
+	stripeRows []*Color // Stripe of each data row, set by striped
+
+	autoIndex   bool   // Number the data rows in a leading column, see SetAutoIndex
+	indexHeader []byte // Header of the index column
+	indexStart  int    // Number of the first data row
+
+	spacers []spacer // Empty columns drawn between the others, see AddSpacerColumn
+
+	rowTemplate *template.Template // Lines drawn under each data row, see SetRowTemplate (nil = none)
+
+	transformers map[int]func(row int, value []byte) []byte // Render-time cell rewrites per column, see SetCellTransformer
+
+	pageSize int                     // Data rows per page for RenderPage (0 = one page)
+	page     int                     // Page RenderPage is rendering plus one (0 = the whole table)
+	maxRows  int                     // Data rows rendered before the rest are counted, see SetMaxRows (0 = all)
+	filter   func(row [][]byte) bool // Rows to render, see SetFilter (nil = all)
+
+	aggregates map[int]Aggregate // Column summaries in the footer, see SetColumnAggregate
+	groupCol   int               // Column to group the rows by, see GroupBy (-1 = none)
+	groupLimit int               // Rows shown per group, see SetGroupLimit (0 = all)
+
+	sortKeys []sortKey // Columns of the SortBy calls so far, in order
+
+	interns *internPool // Shared cell values, see SetInterning (nil = off)
+
+	hotRows int         // Newest data rows kept uncompressed, see SetCompression (0 = off)
+	cold    []coldBlock // Compressed rows, oldest first; their entries in rows are nil
+
+	rowSpans  [][]int // Column spans by position in rows, see AddSpannedRow; may be shorter than rows
+	rowDown   [][]int // Row spans of the cells starting in each column, like rowSpans
+	rowAux    []bool  // Rows the library adds, such as section banners, which SetAutoIndex skips; like rowSpans
+	rowParent []int   // Position in rows of each row's parent plus one, see AddChildRow (0 = top level); like rowSpans
+	rowBelow  []bool  // Lines of SetRowTemplate, which belong to the data row above them; like rowSpans
+
+	mergeCols map[int]bool // Columns whose repeated values are merged, see SetMergeRepeated
+	mergeMark []byte       // Shown in place of merged values (nil = blank)
+
+	dateLocales map[int]dateLocale     // How time.Time values are written per column, see SetDateLocale
+	timeZones   map[int]*time.Location // Zone timestamps are shown in per column, see SetTimeZone
+	columnKinds map[int]ColumnKind     // How values are shown per column (absent = KindText)
+
+	wrapCols map[int]bool // Columns whose long values wrap onto extra lines, see SetWrap
+
 	// Buffer pool for performance
 	bufPool *sync.Pool
 }
@@ -150,11 +245,20 @@ func New(headers ...[]byte) *Table {
 		headers:   make([][]byte, len(headers)),
 		rows:      make([][][]byte, 0),
 		rowKinds:  make([]rowKind, 0),
+		rowASCII:  make([]bool, 0),
 		style:     StyleSingle, // Default to single line style
 		aligns:    make([]Align, len(headers)),
 		maxWidths: make([]int, len(headers)),
 		widthFunc: DefaultWidthFunc, // Default width calculation
+		asciiUnit: unitASCII(DefaultWidthFunc),
 		bufPool:   defaultBufPool,
+
+		escapePolicy: EscapeSanitize, // Untrusted content can't drive the terminal
+		titleAlign:   AlignCenter,
+		indexHeader:  []byte("#"),
+		invalidMark:  []byte("× "),
+		indexStart:   1,
+		groupCol:     -1,
 	}
 
 	// Copy headers to avoid shared slice issues
@@ -175,49 +279,32 @@ func NewFromStrings(headers ...string) *Table {
 	return New(byteHeaders...)
 }
 
-// AddRow adds a row to the table, preferring byte inputs for performance
+// AddRow adds a row to the table, preferring byte inputs for performance.
+// Values implementing encoding.TextMarshaler or fmt.Stringer are rendered
+// with MarshalText or String, in that order, before the %v fallback.
 func (t *Table) AddRow(values ...any) *Table {
 	if len(values) == 0 {
 		return t
 	}
 
 	row := make([][]byte, len(t.headers))
+	t.fillRow(row, values)
+	t.appendRow(row)
+	return t
+}
 
-	for i, val := range values {
-		if i >= len(t.headers) {
-			break // Don't exceed header count
-		}
-
-		// Convert interface{} to []byte efficiently - prioritize []byte inputs
-		switch v := val.(type) {
-		case []byte:
-			// Direct byte slice - make a copy to avoid shared slice issues
-			row[i] = make([]byte, len(v))
-			copy(row[i], v)
-		case string:
-			row[i] = []byte(v) // Only convert when necessary
-		case int:
-			row[i] = strconv.AppendInt(nil, int64(v), 10)
-		case int64:
-			row[i] = strconv.AppendInt(nil, v, 10)
-		case float64:
-			row[i] = strconv.AppendFloat(nil, v, 'f', -1, 64)
-		case bool:
-			row[i] = strconv.AppendBool(nil, v)
-		default:
-			// Fallback to string conversion (avoid this path for performance)
-			row[i] = fmt.Appendf(nil, "%v", v)
-		}
-	}
-
-	// Fill remaining columns with empty bytes if row is shorter
-	for i := len(values); i < len(t.headers); i++ {
-		row[i] = []byte{}
+// AddRowColored adds a row like AddRow and colors it with c, as SetRowColor
+// does, so a row such as a failed check can be tinted as it is added.
+//
+// Example:
+//
+//	t.AddRowColored(tables.NewColor().WithFg(tables.FgRed), "disk", "failed")
+func (t *Table) AddRowColored(c *Color, values ...any) *Table {
+	if len(values) == 0 {
+		return t
 	}
-
-	t.rows = append(t.rows, row)
-	t.rowKinds = append(t.rowKinds, rowData)
-	return t
+	row := t.dataRowsFrom(0)
+	return t.AddRow(values...).SetRowColor(row, c)
 }
 
 // AddRowBytes adds a row from byte slices directly (fastest method)
@@ -226,6 +313,7 @@ func (t *Table) AddRowBytes(values ...[]byte) *Table {
 		return t
 	}
 
+	t.checkRowLength(len(values))
 	row := make([][]byte, len(t.headers))
 
 	for i, val := range values {
@@ -242,9 +330,271 @@ func (t *Table) AddRowBytes(values ...[]byte) *Table {
 		row[i] = []byte{}
 	}
 
+	t.appendRow(row)
+	return t
+}
+
+// AddRows adds many rows at once. It behaves like calling AddRow for each
+// element, but grows the table's storage once up front and carves every
+// row's cells out of a single allocation, which is much faster for large
+// batches. Empty rows are skipped, as with AddRow.
+//
+// Example:
+//
+//	t.AddRows([][]any{
+//	    {"Alice", 30},
+//	    {"Bob", 25},
+//	})
+func (t *Table) AddRows(rows [][]any) *Table {
+	t.growRows(len(rows))
+
+	cells := make([][]byte, len(rows)*len(t.headers))
+	for _, values := range rows {
+		if len(values) == 0 {
+			continue
+		}
+		row := cells[:len(t.headers):len(t.headers)]
+		cells = cells[len(t.headers):]
+		t.fillRow(row, values)
+		t.appendRow(row)
+	}
+	return t
+}
+
+// AddRowsBytes adds many rows of byte slices at once, the bulk counterpart of
+// AddRowBytes. All cell contents are copied into one buffer sized from the
+// input, so a batch costs a handful of allocations instead of one per cell.
+func (t *Table) AddRowsBytes(rows [][][]byte) *Table {
+	t.growRows(len(rows))
+
+	size := 0
+	for _, values := range rows {
+		for i, val := range values {
+			if i >= len(t.headers) {
+				break
+			}
+			if !t.nfc {
+				if _, ok := t.intern(i, val); ok {
+					continue // shared below rather than copied
+				}
+			}
+			size += len(val)
+		}
+	}
+	buf := make([]byte, 0, size)
+	cells := make([][]byte, len(rows)*len(t.headers))
+
+	for _, values := range rows {
+		if len(values) == 0 {
+			continue
+		}
+		t.checkRowLength(len(values))
+		row := cells[:len(t.headers):len(t.headers)]
+		cells = cells[len(t.headers):]
+		for i := range row {
+			if i >= len(values) {
+				row[i] = []byte{}
+				continue
+			}
+			if !t.nfc {
+				if v, ok := t.interned(values[i]); ok {
+					row[i] = v // interned while sizing buf, no need to copy
+					continue
+				}
+			}
+			// Cap each cell so appending to one can't overwrite the next
+			start := len(buf)
+			buf = append(buf, values[i]...)
+			row[i] = buf[start:len(buf):len(buf)]
+		}
+		t.appendRow(row)
+	}
+	return t
+}
+
+// SetCell replaces the value of one data cell at (row, col), both 0-indexed
+// with rows numbered like SetRowColor (separators not counted). The value is
+// converted like AddRow's. Out-of-range positions are a no-op and record an
+// error for Err.
+//
+// Changed rows are tracked, so a Live display redraws only those rows on its
+// next Update.
+func (t *Table) SetCell(row, col int, value any) *Table {
+	t.thaw()
+	pos := t.rowPos(row)
+	if pos < 0 || !t.checkColumn(col) {
+		return t
+	}
+
+	t.rows[pos][col] = t.cellBytes(value)
+	t.rowChanged(pos)
+	return t
+}
+
+// UpdateRow replaces all values of data row row (0-indexed, separators not
+// counted), padding or cutting values to the header count like AddRow. A row
+// added with AddSpannedRow becomes a plain one. An out-of-range row is a
+// no-op and records an error for Err.
+func (t *Table) UpdateRow(row int, values ...any) *Table {
+	t.thaw()
+	pos := t.rowPos(row)
+	if pos < 0 {
+		return t
+	}
+
+	cells := make([][]byte, len(t.headers))
+	t.fillRow(cells, values)
+	t.rows[pos] = cells
+	if t.spansAt(pos) != nil || pos < len(t.rowDown) && t.rowDown[pos] != nil {
+		t.setSpans(pos, nil)
+		if pos < len(t.rowDown) {
+			t.rowDown[pos] = nil
+		}
+		t.dirtyAll = true // the borders around the row change too
+	}
+	if t.isAux(pos) {
+		t.rowAux[pos] = false // a plain data row now
+	}
+	t.rowChanged(pos)
+	return t
+}
+
+// rowPos returns the position in t.rows of data row row, or -1 after
+// recording an error if there is no such row.
+func (t *Table) rowPos(row int) int {
+	if row >= 0 {
+		idx := 0
+		for pos, kind := range t.rowKinds {
+			if kind != rowData {
+				continue
+			}
+			if idx == row {
+				return pos
+			}
+			idx++
+		}
+	}
+	t.setErr(fmt.Errorf("%w: row %d, table has %d", ErrRowOutOfRange, row, t.dataRowsFrom(0)))
+	return -1
+}
+
+// rowChanged refreshes the metadata of the row at pos after its cells were
+// replaced, and marks it for redrawing.
+func (t *Table) rowChanged(pos int) {
+	ascii := rowIsASCII(t.rows[pos])
+	if t.nfc && !ascii {
+		normalizeCells(t.rows[pos])
+	}
+	if t.interns != nil {
+		t.internRow(t.rows[pos])
+	}
+	t.rowASCII[pos] = ascii
+
+	if t.dirty == nil {
+		t.dirty = make(map[int]bool)
+	}
+	t.dirty[pos] = true
+}
+
+// fillRow converts values into the cells of row, which has one slot per
+// header. Extra values are dropped and missing ones left empty.
+func (t *Table) fillRow(row [][]byte, values []any) {
+	t.checkRowLength(len(values))
+	for i, val := range values {
+		if i >= len(row) {
+			break // Don't exceed header count
+		}
+
+		row[i] = t.cellBytes(val)
+	}
+
+	// Fill remaining columns with empty bytes if row is shorter
+	for i := len(values); i < len(row); i++ {
+		row[i] = []byte{}
+	}
+}
+
+// cellBytes converts val into the contents of a cell.
+func (t *Table) cellBytes(val any) []byte {
+	// Convert interface{} to []byte efficiently - prioritize []byte inputs
+	switch v := val.(type) {
+	case []byte:
+		// Direct byte slice - make a copy to avoid shared slice issues
+		cell := make([]byte, len(v))
+		copy(cell, v)
+		return cell
+	case string:
+		return []byte(v) // Only convert when necessary
+	case int:
+		return strconv.AppendInt(nil, int64(v), 10)
+	case int64:
+		return strconv.AppendInt(nil, v, 10)
+	case float64:
+		return strconv.AppendFloat(nil, v, 'f', -1, 64)
+	case bool:
+		return strconv.AppendBool(nil, v)
+	case encoding.TextMarshaler:
+		// Domain types (IPs, UUIDs, times) already know their text form
+		if text, err := v.MarshalText(); err == nil {
+			return text
+		}
+		return fmt.Appendf(nil, "%v", v)
+	case fmt.Stringer:
+		return []byte(v.String())
+	default:
+		// Fallback to string conversion (avoid this path for performance)
+		return fmt.Appendf(nil, "%v", v)
+	}
+}
+
+// growRows makes room for n more rows in rows and its parallel slices, so a
+// batch of appends reallocates at most once.
+func (t *Table) growRows(n int) {
+	t.rows = slices.Grow(t.rows, n)
+	t.rowKinds = slices.Grow(t.rowKinds, n)
+	t.rowASCII = slices.Grow(t.rowASCII, n)
+}
+
+// appendRow appends a fully built data row, tagging it for the ASCII fast
+// path in the same pass so measuring and aligning can skip UTF-8 decoding.
+// Non-ASCII rows are NFC-normalized here when SetNormalizeNFC is on, and
+// cells are interned when SetInterning is.
+func (t *Table) appendRow(row [][]byte) {
+	ascii := rowIsASCII(row)
+	if t.nfc && !ascii {
+		normalizeCells(row)
+	}
+	if t.interns != nil {
+		t.internRow(row)
+	}
 	t.rows = append(t.rows, row)
 	t.rowKinds = append(t.rowKinds, rowData)
-	return t
+	t.rowASCII = append(t.rowASCII, ascii)
+	if t.hotRows > 0 {
+		t.compressCold()
+	}
+}
+
+// permuteRows reorders rows and every slice kept parallel to them so that
+// position i holds what was previously at perm[i].
+func (t *Table) permuteRows(perm []int) {
+	rows := make([][][]byte, len(perm))
+	kinds := make([]rowKind, len(perm))
+	ascii := make([]bool, len(perm))
+	spans := permuteSpans(t.rowSpans, perm)
+	down := permuteSpans(t.rowDown, perm)
+	aux := permuteSpans(t.rowAux, perm)
+	parents := permuteParents(t.rowParent, perm)
+	below := permuteSpans(t.rowBelow, perm)
+	t.moveColors(perm)
+	for i, p := range perm {
+		rows[i] = t.rows[p]
+		kinds[i] = t.rowKinds[p]
+		ascii[i] = t.rowASCII[p]
+	}
+	t.rows, t.rowKinds, t.rowASCII, t.rowSpans, t.rowDown = rows, kinds, ascii, spans, down
+	t.rowAux, t.rowParent, t.rowBelow = aux, parents, below
+	t.dirtyAll = true // every row may have moved
 }
 
 // AddSeparator inserts a horizontal separator line at the current position in
@@ -264,18 +614,24 @@ func (t *Table) AddSeparator() *Table {
 	// the renderer can identify it quickly without allocating a full cell slice.
 	t.rows = append(t.rows, nil)
 	t.rowKinds = append(t.rowKinds, rowSeparator)
+	t.rowASCII = append(t.rowASCII, true)
 	return t
 }
 
-// SetStyle sets the border style for the table
+// SetStyle sets the border style for the table. A style that fails
+// Style.Validate is still used, as it always was, but its error is recorded
+// for Err.
 func (t *Table) SetStyle(style Style) *Table {
+	if err := style.Validate(); err != nil {
+		t.setErr(err)
+	}
 	t.style = style
 	return t
 }
 
 // SetAlign sets alignment for a specific column
 func (t *Table) SetAlign(col int, align Align) *Table {
-	if col >= 0 && col < len(t.aligns) {
+	if t.checkColumn(col) {
 		t.aligns[col] = align
 	}
 	return t
@@ -283,18 +639,118 @@ func (t *Table) SetAlign(col int, align Align) *Table {
 
 // SetMaxWidth sets maximum width for a specific column
 func (t *Table) SetMaxWidth(col int, width int) *Table {
-	if col >= 0 && col < len(t.maxWidths) {
+	if t.checkColumn(col) {
 		t.maxWidths[col] = width
 	}
 	return t
 }
 
+// SetHeader replaces the header text of column col (0-indexed), e.g. to
+// prettify names that came from SQL or CSV. Data is not affected.
+func (t *Table) SetHeader(col int, name string) *Table {
+	if t.checkColumn(col) {
+		t.headers[col] = []byte(name)
+		if t.nfc {
+			t.headers[col] = normalizeNFC(t.headers[col])
+		}
+	}
+	return t
+}
+
+// RenameHeader renames the first column whose header is oldName. If there is
+// no such column the call is a no-op and an error wrapping
+// ErrColumnOutOfRange is recorded for Err.
+func (t *Table) RenameHeader(oldName, newName string) *Table {
+	col := t.headerIndex(oldName)
+	if col < 0 {
+		t.setErr(fmt.Errorf("%w: no column named %q", ErrColumnOutOfRange, oldName))
+		return t
+	}
+	return t.SetHeader(col, newName)
+}
+
 // SetWidthFunc sets a custom width calculation function
 func (t *Table) SetWidthFunc(fn WidthFunc) *Table {
-	t.widthFunc = fn
+	t.baseWidth = fn
+	t.widthFunc = withEmojiWidth(fn, t.emojiWide)
+	t.asciiUnit = unitASCII(t.widthFunc)
 	return t
 }
 
+// SetEmojiWidth sets how many columns emoji and pictographic symbols (😀, ✅,
+// ★) take: 2, as most desktop terminals draw them, or 1, as many CI log
+// viewers and older terminals do, where the wide default misaligns the
+// borders. Any other n goes back to what the width function says. The
+// setting sits on top of SetWidthFunc, so CJK and everything else is still
+// measured by it.
+//
+// Example:
+//
+//	if os.Getenv("CI") != "" {
+//	    t.SetEmojiWidth(1)
+//	}
+func (t *Table) SetEmojiWidth(n int) *Table {
+	if n != 1 && n != 2 {
+		n = 0
+	}
+	if t.baseWidth == nil {
+		t.baseWidth = t.widthFunc
+	}
+	t.emojiWide = n
+	return t.SetWidthFunc(t.baseWidth)
+}
+
+// SetMaxOutputBytes caps the size of the rendered table at n bytes. When the
+// full table would be larger, rendering stops after the last data row that
+// fits, the table is closed with its bottom border, and a notice saying how
+// many rows were left out is appended; the footer is omitted. This protects
+// log pipelines from a table that unexpectedly grew to millions of rows.
+// n <= 0 removes the cap.
+func (t *Table) SetMaxOutputBytes(n int) *Table {
+	t.maxOutput = max(n, 0)
+	return t
+}
+
+// LockWidths freezes the column widths computed from the table's current
+// contents. Every later render reuses exactly these widths, even after rows are
+// added, so periodically re-printed tables keep a stable geometry instead of
+// jittering as values change. Content wider than a locked column is truncated
+// just like with SetMaxWidth.
+func (t *Table) LockWidths() *Table {
+	t.locked = t.warm().measureColumns()
+	return t
+}
+
+// UnlockWidths discards widths frozen by LockWidths so columns are measured
+// again on every render.
+func (t *Table) UnlockWidths() *Table {
+	t.locked = nil
+	return t
+}
+
+// columnWidths returns the widths to render with: the natural widths,
+// narrowed to the terminal width when fitting to one.
+func (t *Table) columnWidths() []int {
+	widths := t.naturalWidths()
+	if t.fitWidth > 0 {
+		widths = t.fitColumns(widths)
+	}
+	return widths
+}
+
+// naturalWidths returns the locked widths if LockWidths was called,
+// otherwise freshly measured ones, widened to fit the title.
+func (t *Table) naturalWidths() []int {
+	widths := t.locked
+	if widths == nil || len(widths) != len(t.headers) {
+		widths = t.measureColumns()
+	}
+	if t.title != nil {
+		widths = t.widenForTitle(widths)
+	}
+	return widths
+}
+
 // measureColumns calculates the width needed for each column
 func (t *Table) measureColumns() []int {
 	if len(t.headers) == 0 {
@@ -305,7 +761,7 @@ func (t *Table) measureColumns() []int {
 
 	// Measure header widths using ANSI-aware width calculation
 	for i, header := range t.headers {
-		widths[i] = MeasureWidthIgnoreANSIBytesCustom(header, t.widthFunc)
+		widths[i] = t.cellWidth(header, isPrintableASCII(header))
 	}
 
 	// Measure row widths
@@ -315,9 +771,17 @@ func (t *Table) measureColumns() []int {
 			continue // separators don't affect column widths
 		}
 
+		ascii := t.rowASCII[i]
+		spans := t.spansAt(i)
 		for i, cell := range row {
+			if spans != nil && spans[i] != 1 {
+				continue // spanned cells are fitted below
+			}
 			if i < len(widths) {
-				cellWidth := MeasureWidthIgnoreANSIBytesCustom(cell, t.widthFunc)
+				if t.showsTimes() {
+					cell = t.timeCell(cell, i)
+				}
+				cellWidth := t.cellWidth(cell, ascii)
 				// if cellWidth > widths[i] {
 				// 	widths[i] = cellWidth
 				// }
@@ -329,7 +793,7 @@ func (t *Table) measureColumns() []int {
 	if t.footer != nil {
 		for j, cell := range t.footer {
 			if j < len(widths) {
-				if w := MeasureWidthIgnoreANSIBytesCustom(cell, t.widthFunc); w > widths[j] {
+				if w := t.cellWidth(cell, isPrintableASCII(cell)); w > widths[j] {
 					widths[j] = w
 				}
 			}
@@ -342,17 +806,60 @@ func (t *Table) measureColumns() []int {
 			widths[i] = maxWidth
 		}
 	}
+	t.widenForSpans(widths)
+	if t.mergeMark != nil {
+		w := t.cellWidth(t.mergeMark, isPrintableASCII(t.mergeMark))
+		for col := range t.mergeCols {
+			widths[col] = max(widths[col], w)
+		}
+	}
 
 	return widths
 }
 
+// display returns the bytes a cell in column col renders as after the
+// render-time content settings (escape policy, wide-glyph emulation,
+// compatibility replacement, auto-linking) are applied. It is used for both measuring and rendering so
+// the two always agree. col is -1 for header and footer cells.
+func (t *Table) display(cell []byte, col int) []byte {
+	cell = applyEscapePolicy(cell, t.escapePolicy)
+	if t.wideEmulation {
+		cell = emulateWide(cell, t.widthFunc)
+	}
+	if t.compatMode {
+		cell = t.compatCell(cell)
+	}
+	if t.autoLinked(col) {
+		cell = linkify(cell)
+	}
+	return cell
+}
+
+// cellWidth returns the display width of a stored cell. Cells known to be
+// printable ASCII are measured by their length alone.
+func (t *Table) cellWidth(cell []byte, ascii bool) int {
+	if ascii && t.asciiUnit {
+		return len(cell)
+	}
+	return MeasureWidthIgnoreANSIBytesCustom(t.display(cell, -1), t.widthFunc)
+}
+
 // alignCell aligns a cell's content within the given width
-func (t *Table) alignCell(cell []byte, width int, align Align) []byte {
+func (t *Table) alignCell(cell []byte, width int, align Align, ascii bool) []byte {
+	if ascii && t.asciiUnit {
+		if len(cell) >= width {
+			return cell[:width:width]
+		}
+		return t.padWithANSI(cell, width, len(cell), align)
+	}
+
 	cellWidth := MeasureWidthIgnoreANSIBytesCustom(cell, t.widthFunc)
 
-	if cellWidth >= width {
-		// Truncate if too long - need to preserve ANSI sequences
-		return t.truncateWithANSI(cell, width)
+	if cellWidth > width {
+		// Truncate if too long - need to preserve ANSI sequences. A wide
+		// rune that doesn't fit can leave the result a column short.
+		cell = t.truncateWithANSI(cell, width)
+		cellWidth = MeasureWidthIgnoreANSIBytesCustom(cell, t.widthFunc)
 	}
 
 	// Pad the cell while preserving ANSI sequences
@@ -362,7 +869,7 @@ func (t *Table) alignCell(cell []byte, width int, align Align) []byte {
 // truncateWithANSI truncates text while preserving ANSI sequences
 func (t *Table) truncateWithANSI(cell []byte, maxWidth int) []byte {
 	if !HasANSIBytes(cell) {
-		return TruncateToWidthBytes(cell, maxWidth)
+		return truncateToWidthBytesCustom(cell, maxWidth, t.widthFunc)
 	}
 
 	// For ANSI text, we need to be more careful
@@ -373,7 +880,7 @@ func (t *Table) truncateWithANSI(cell []byte, maxWidth int) []byte {
 	}
 
 	// Truncate the stripped version and add ellipsis
-	truncated := TruncateToWidthBytes(stripped, maxWidth)
+	truncated := truncateToWidthBytesCustom(stripped, maxWidth, t.widthFunc)
 	return truncated
 }
 
@@ -440,34 +947,87 @@ func (t *Table) renderBorder(buf *bytes.Buffer, widths []int, borderType string)
 
 	// Use the style to render the border
 	borderBytes := t.style.renderBorderLine(widths, borderType)
+	mark := buf.Len()
 	buf.Write(borderBytes)
+	t.colorBorder(buf, mark)
 }
 
-// renderRow renders a single data row using the table's style
-func (t *Table) renderRow(buf *bytes.Buffer, row [][]byte, widths []int, rowIdx int) {
+// renderRow renders a single data row using the table's style, on as many
+// lines as its wrapped columns need (see SetWrap), surrounded by any blank
+// lines requested via SetVerticalPadding and SetRowHeight. spans are the
+// row's column spans, nil for a plain row.
+func (t *Table) renderRow(buf *bytes.Buffer, row [][]byte, widths []int, rowIdx int, ascii bool, spans []int) {
 	if len(widths) == 0 {
 		return
 	}
 
+	lines := [][][]byte{row}
+	if t.wrapCols != nil {
+		if wrapped := t.wrapRow(row, widths, rowIdx, ascii, spans); wrapped != nil {
+			lines = wrapped
+		}
+	}
+
+	top, bottom := t.rowPadding(rowIdx, len(lines))
+	for range top {
+		t.renderLine(buf, nil, widths, rowIdx, true, spans)
+	}
+	for _, line := range lines {
+		t.renderLine(buf, line, widths, rowIdx, ascii, spans)
+	}
+	for range bottom {
+		t.renderLine(buf, nil, widths, rowIdx, true, spans)
+	}
+}
+
+// renderLine renders one physical line of a row. A nil row produces a blank
+// line that still carries the row's colors, so padded rows look uniform.
+// ascii reports whether every cell of row is printable ASCII.
+func (t *Table) renderLine(buf *bytes.Buffer, row [][]byte, widths []int, rowIdx int, ascii bool, spans []int) {
 	// Use vertical character from style
-	verticalChar := t.style.Vertical
+	verticalChar := t.borderColor.Apply(string(t.style.Vertical))
+	stripe := t.stripeAt(rowIdx)
 
-	buf.WriteRune(verticalChar) // Left border
+	buf.WriteString(verticalChar) // Left border
 
 	for i, width := range widths {
-		buf.WriteByte(' ') // Left padding
+		if spans != nil {
+			if spans[i] == 0 {
+				continue // covered by the cell to the left
+			}
+			width = spanWidth(widths[i : i+spans[i]])
+		}
 
 		var cell []byte
 		if i < len(row) {
 			cell = row[i]
 		}
+		stored := cell
+		if rowIdx >= 0 && t.showsTimes() {
+			cell = t.timeCell(cell, i)
+		}
+		cellASCII := ascii
+		if rowIdx >= 0 && t.diff != nil && row != nil {
+			cell = t.diffCell(row, i)
+			cellASCII = ascii && !t.diff.covers(i)
+		}
+		col := i
+		if rowIdx < 0 {
+			col = -1 // header and footer are never linked
+		}
+		if !cellASCII {
+			cell = t.display(cell, col)
+		} else if t.autoLinked(col) {
+			cell = linkify(cell)
+			cellASCII = false // now carries escape sequences
+		}
 
 		align := AlignLeft
 		if i < len(t.aligns) {
 			align = t.aligns[i]
 		}
 
-		aligned := string(t.alignCell(cell, width, align))
+		aligned := string(t.alignCell(cell, width, align, cellASCII))
 
 		// apply color — header vs data row
 		switch rowIdx {
@@ -476,12 +1036,17 @@ func (t *Table) renderRow(buf *bytes.Buffer, row [][]byte, widths []int, rowIdx
 		case -2:
 			aligned = t.footerColor.Apply(aligned)
 		default:
-			aligned = t.cellColor(rowIdx, i).Apply(aligned)
+			aligned = t.cellColor(rowIdx, i, stored).Apply(aligned)
 		}
 
-		buf.WriteString(aligned)
-		buf.WriteByte(' ')          // Right padding
-		buf.WriteRune(verticalChar) // Column separator / Right border
+		if stripe != nil {
+			buf.WriteString(applyStripe(stripe, " "+aligned+" "))
+		} else {
+			buf.WriteByte(' ') // Left padding
+			buf.WriteString(aligned)
+			buf.WriteByte(' ') // Right padding
+		}
+		buf.WriteString(verticalChar) // Column separator / Right border
 	}
 
 	buf.WriteByte('\n')
@@ -489,34 +1054,47 @@ func (t *Table) renderRow(buf *bytes.Buffer, row [][]byte, widths []int, rowIdx
 
 // render writes the complete table into buf.
 func (t *Table) render(buf *bytes.Buffer) {
-	if len(t.headers) == 0 {
-		return
+	// The only error the text backend returns is errOutputLimit, after it has
+	// already closed the table and written the truncation notice.
+	v := t.shown()
+	_ = v.Render(&textBackend{t: v, buf: buf})
+}
+
+// renderTruncated closes a table cut short by SetMaxOutputBytes: the bottom
+// border (skipped when widths is nil, i.e. not even the header fit) below a
+// row with column spans above, followed by a notice naming how many of the
+// data rows were left out.
+func (t *Table) renderTruncated(buf *bytes.Buffer, widths, above []int, omitted int) {
+	if widths != nil {
+		t.renderJoinedBorder(buf, widths, "bottom", above, nil, nil)
 	}
+	buf.WriteString(truncationNotice(omitted, t.dataRowsFrom(0)))
+}
 
-	widths := t.measureColumns()
+// truncationNotice is the line appended to output cut by SetMaxOutputBytes.
+func truncationNotice(omitted, total int) string {
+	if omitted == 0 {
+		return "... output truncated: footer not shown\n"
+	}
+	return fmt.Sprintf("... output truncated: %d of %d rows not shown\n", omitted, total)
+}
 
-	t.renderBorder(buf, widths, "top")
-	t.renderRow(buf, t.headers, widths, -1)      // -1 = header
-	t.renderBorder(buf, widths, "middle")
+// borderLen returns the byte length of one rendered border line.
+func (t *Table) borderLen(widths []int) int {
+	var b bytes.Buffer
+	t.renderBorder(&b, widths, "bottom")
+	return b.Len()
+}
 
-	dataIdx := 0
-	for i, row := range t.rows {
-		if t.rowKinds[i] == rowSeparator {
-			t.renderBorder(buf, widths, "middle")
-			continue
+// dataRowsFrom counts the data rows from position i on, skipping separators.
+func (t *Table) dataRowsFrom(i int) int {
+	n := 0
+	for _, kind := range t.rowKinds[i:] {
+		if kind == rowData {
+			n++
 		}
-		t.renderRow(buf, row, widths, dataIdx)
-		dataIdx++
-	}
-
-	// replace the final renderBorder call at the bottom of render():
-	if t.footer != nil {
-		t.renderBorder(buf, widths, "middle")
-		t.renderRow(buf, t.footer, widths, -2) // -2 = footer sentinel
-		t.renderBorder(buf, widths, "bottom")
-	} else {
-		t.renderBorder(buf, widths, "bottom")
 	}
+	return n
 }
 
 // String returns the formatted table as a string
@@ -538,12 +1116,14 @@ func (t *Table) String() string {
 	return string(result)
 }
 
-// Print prints the table directly to stdout
+// Print prints the table directly to stdout, uncolored unless stdout is a
+// terminal (see SetColorMode).
 func (t *Table) Print() {
-	fmt.Print(t.String())
+	t.WriteTo(os.Stdout)
 }
 
-// WriteTo writes the table to any io.Writer
+// WriteTo writes the table to any io.Writer, stripping ANSI sequences when w
+// isn't a terminal (see SetColorMode).
 func (t *Table) WriteTo(w io.Writer) (int64, error) {
 	if len(t.headers) == 0 {
 		return 0, nil
@@ -555,6 +1135,10 @@ func (t *Table) WriteTo(w io.Writer) (int64, error) {
 	defer t.bufPool.Put(buf)
 
 	t.render(buf)
+	if !t.colorMode.colorsFor(w) && HasANSIBytes(buf.Bytes()) {
+		n, err := w.Write(StripANSIBytes(buf.Bytes()))
+		return int64(n), err
+	}
 	// Write directly from buffer to avoid string conversion
 	return buf.WriteTo(w)
 }
@@ -3,6 +3,7 @@
 package tables
 
 import (
+	"strconv"
 	"unicode/utf8"
 )
 
@@ -101,30 +102,95 @@ var (
 	}
 )
 
+// BorderPosition names one of the runes a Style draws borders with.
+type BorderPosition int
+
+const (
+	BorderTopLeft BorderPosition = iota
+	BorderTopRight
+	BorderBottomLeft
+	BorderBottomRight
+	BorderHorizontal
+	BorderVertical
+	BorderCross
+	BorderTopTee
+	BorderBottomTee
+	BorderLeftTee
+	BorderRightTee
+)
+
+var borderPositionNames = [...]string{
+	"TopLeft", "TopRight", "BottomLeft", "BottomRight", "Horizontal",
+	"Vertical", "Cross", "TopTee", "BottomTee", "LeftTee", "RightTee",
+}
+
+// String returns the name of the Style field for the position, such as
+// "TopLeft".
+func (p BorderPosition) String() string {
+	if p < 0 || int(p) >= len(borderPositionNames) {
+		return "BorderPosition(" + strconv.Itoa(int(p)) + ")"
+	}
+	return borderPositionNames[p]
+}
+
+// Char returns the rune the style draws at pos, or ' ' for an unknown
+// position.
+func (s Style) Char(pos BorderPosition) rune {
+	switch pos {
+	case BorderTopLeft:
+		return s.TopLeft
+	case BorderTopRight:
+		return s.TopRight
+	case BorderBottomLeft:
+		return s.BottomLeft
+	case BorderBottomRight:
+		return s.BottomRight
+	case BorderHorizontal:
+		return s.Horizontal
+	case BorderVertical:
+		return s.Vertical
+	case BorderCross:
+		return s.Cross
+	case BorderTopTee:
+		return s.TopTee
+	case BorderBottomTee:
+		return s.BottomTee
+	case BorderLeftTee:
+		return s.LeftTee
+	case BorderRightTee:
+		return s.RightTee
+	}
+	return ' '
+}
+
+// GetBorderChar returns the rune the style draws at the named position,
+// such as "top-left" or "tl", or ' ' for an unknown name.
+//
+// Deprecated: Use Char with a BorderPosition, which the compiler can check.
 func (s Style) GetBorderChar(position string) rune {
 	switch position {
 	case "top-left", "tl", "topleft", "topl", "tleft", "tlft":
-		return s.TopLeft
+		return s.Char(BorderTopLeft)
 	case "top-right", "tr", "topright", "topr":
-		return s.TopRight
+		return s.Char(BorderTopRight)
 	case "bottom-left", "btm-lft", "bl", "btmleft", "btml":
-		return s.BottomLeft
+		return s.Char(BorderBottomLeft)
 	case "bottom-right", "br", "bottomright", "botr":
-		return s.BottomRight
+		return s.Char(BorderBottomRight)
 	case "horizontal", "h":
-		return s.Horizontal
+		return s.Char(BorderHorizontal)
 	case "vertical", "v":
-		return s.Vertical
+		return s.Char(BorderVertical)
 	case "cross":
-		return s.Cross
+		return s.Char(BorderCross)
 	case "top-tee", "tt", "t-tee":
-		return s.TopTee
+		return s.Char(BorderTopTee)
 	case "bottom-tee", "btm-tee", "btm-t", "btmt":
-		return s.BottomTee
+		return s.Char(BorderBottomTee)
 	case "left-tee", "lt", "l-tee":
-		return s.LeftTee
+		return s.Char(BorderLeftTee)
 	case "right-tee", "rt", "r-tee":
-		return s.RightTee
+		return s.Char(BorderRightTee)
 	default:
 		return ' '
 	}
@@ -163,6 +229,11 @@ func (s Style) renderBorderLine(widths []int, lineType string) []byte {
 		endChar = s.TopRight
 		sepChar = s.TopTee
 		fillChar = s.Horizontal
+	case "title": // below a title box, where the columns begin
+		startChar = s.LeftTee
+		endChar = s.RightTee
+		sepChar = s.TopTee
+		fillChar = s.Horizontal
 	case "middle", "header":
 		startChar = s.LeftTee
 		endChar = s.RightTee
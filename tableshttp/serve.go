@@ -0,0 +1,65 @@
+// Package tableshttp serves table snapshots, as returned by Table.Publish,
+// over HTTP: as text to curl, as HTML to browsers and as JSON to API clients,
+// with the caching headers that let pollers skip unchanged tables.
+package tableshttp
+
+import (
+	"net/http"
+	"strings"
+
+	tables "github.com/architmishra-15/go-tables"
+)
+
+// ServeSnapshot writes s in the format the request asks for: the one named
+// by a "format" query parameter ("text", "html" or "json"), or else HTML for
+// an Accept header listing text/html, as browsers send, JSON for one listing
+// application/json, and text otherwise. Responses carry an ETag and
+// Last-Modified, so clients polling for changes get 304 Not Modified until a
+// new snapshot is served, and HEAD and range requests work as with
+// http.ServeContent. A nil s, such as an atomic.Pointer loaded before the
+// first snapshot is stored, gets 503 Service Unavailable.
+//
+// Example:
+//
+//	var current atomic.Pointer[tables.Snapshot]
+//	current.Store(t.Publish())
+//
+//	http.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+//	    tableshttp.ServeSnapshot(w, r, current.Load())
+//	})
+func ServeSnapshot(w http.ResponseWriter, r *http.Request, s *tables.Snapshot) {
+	if s == nil {
+		http.Error(w, "no snapshot published yet", http.StatusServiceUnavailable)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		accept := r.Header.Get("Accept")
+		switch {
+		case strings.Contains(accept, "text/html"):
+			format = "html"
+		case strings.Contains(accept, "application/json"):
+			format = "json"
+		default:
+			format = "text"
+		}
+	}
+
+	body, ctype := s.Text(), "text/plain; charset=utf-8"
+	switch format {
+	case "html":
+		body, ctype = s.HTML(), "text/html; charset=utf-8"
+	case "json":
+		body, ctype = s.JSON(), "application/json"
+	case "text":
+	default:
+		http.Error(w, "unknown format "+format, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", ctype)
+	w.Header().Set("ETag", `"`+s.ETag()+"-"+format+`"`)
+	w.Header().Add("Vary", "Accept")
+	http.ServeContent(w, r, "", s.Time(), strings.NewReader(body))
+}
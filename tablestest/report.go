@@ -0,0 +1,91 @@
+// Package tablestest renders table-driven test results as tables.
+//
+// It lives in its own package so that importing the main tables package never
+// pulls in the testing package.
+package tablestest
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	tables "github.com/architmishra-15/go-tables"
+)
+
+// Case is a single table-driven test case result.
+//
+// A case passes when Err is nil and Expected and Got are deeply equal.
+type Case struct {
+	Name     string
+	Input    any
+	Expected any
+	Got      any
+	Err      error
+}
+
+// Passed reports whether the case succeeded.
+func (c Case) Passed() bool {
+	return c.Err == nil && reflect.DeepEqual(c.Expected, c.Got)
+}
+
+// Report fails t and logs a table of every case when at least one case
+// failed. Passing runs produce no output. The Expected and Got columns are
+// rendered as an inline diff, so small mismatches are easy to spot.
+//
+// Example:
+//
+//	var cases []tablestest.Case
+//	for _, tc := range tests {
+//	    got, err := Parse(tc.in)
+//	    cases = append(cases, tablestest.Case{
+//	        Name: tc.name, Input: tc.in, Expected: tc.want, Got: got, Err: err,
+//	    })
+//	}
+//	tablestest.Report(t, cases)
+func Report(t testing.TB, cases []Case) {
+	t.Helper()
+
+	failed := 0
+	for _, c := range cases {
+		if !c.Passed() {
+			failed++
+		}
+	}
+	if failed == 0 {
+		return
+	}
+
+	t.Errorf("%d of %d cases failed:\n%s", failed, len(cases), Table(cases).String())
+}
+
+// Table builds the report table for cases without touching a testing.TB, for
+// callers that want to print or export it themselves.
+func Table(cases []Case) *tables.Table {
+	t := tables.NewFromStrings("Case", "Input", "Expected", "Got", "Status").
+		SetStyle(tables.StyleRounded).
+		SetHeaderColor(tables.NewColor().WithStyle(tables.Bold)).
+		SetDiffColumns(2, 3).
+		SetAlign(4, tables.AlignCenter)
+
+	for _, c := range cases {
+		status := tables.Success("PASS")
+		got := formatValue(c.Got)
+		if !c.Passed() {
+			status = tables.Error("FAIL")
+		}
+		if c.Err != nil {
+			got = "error: " + c.Err.Error()
+		}
+		t.AddRow(c.Name, formatValue(c.Input), formatValue(c.Expected), got, status)
+	}
+	return t
+}
+
+// formatValue quotes strings so whitespace differences stay visible and uses
+// %v for everything else.
+func formatValue(v any) string {
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,119 @@
+// template.go
+
+package tables
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// templateIndent is written in front of each line of a row template.
+const templateIndent = "  "
+
+// SetRowTemplate draws free-form lines under every data row, such as a
+// description or a stack trace below a summary, from a text/template applied
+// to the row. The template sees the row as a map from header to cell value,
+// so {{.Name}} is the value in the "Name" column and {{index . "Full Name"}}
+// one whose header isn't an identifier. Each line it produces spans the
+// table, indented inside the borders; a row it produces nothing for gets no
+// lines. A template that fails for a row shows the error in their place.
+// Rows added with AddSpannedRow and AddSection banners get no lines.
+//
+// Only the text output shows the lines: Rows and the exporters see the data
+// rows alone. A template that doesn't parse records ErrInvalidTemplate and
+// leaves the setting unchanged; an empty one removes it.
+//
+// Example:
+//
+//	t.SetRowTemplate("{{if .Error}}error: {{.Error}}{{end}}")
+//
+//	│ build │ ok     │       │
+//	│ test  │ failed │ EOF   │
+//	│   error: EOF           │
+//	│ lint  │ ok     │       │
+func (t *Table) SetRowTemplate(tmpl string) *Table {
+	if tmpl == "" {
+		t.rowTemplate = nil
+		return t
+	}
+	parsed, err := template.New("row").Option("missingkey=zero").Parse(tmpl)
+	if err != nil {
+		t.setErr(fmt.Errorf("%w: %v", ErrInvalidTemplate, err))
+		return t
+	}
+	t.rowTemplate = parsed
+	return t
+}
+
+// templated returns t itself, or with SetRowTemplate a copy with the lines of
+// the template below each data row.
+func (t *Table) templated() *Table {
+	if t.rowTemplate == nil {
+		return t
+	}
+
+	cp := *t
+	cp.rowTemplate = nil
+	cp.rows = slices.Clone(t.rows)
+	cp.rowKinds = slices.Clone(t.rowKinds)
+	cp.rowASCII = slices.Clone(t.rowASCII)
+
+	// The lines are appended after the rows, then moved below their own row.
+	perm := make([]int, 0, len(t.rows))
+	data := make(map[string]string, len(t.headers))
+	var buf bytes.Buffer
+	for pos, kind := range t.rowKinds {
+		perm = append(perm, pos)
+		if kind != rowData || t.spansAt(pos) != nil || t.isAux(pos) {
+			continue
+		}
+		for col, header := range t.headers {
+			data[string(header)] = cellString(t.rows[pos], col)
+		}
+		buf.Reset()
+		if err := t.rowTemplate.Execute(&buf, data); err != nil {
+			buf.Reset()
+			buf.WriteString(err.Error())
+		}
+		text := strings.TrimRight(buf.String(), "\n")
+		if text == "" {
+			continue
+		}
+		for _, line := range strings.Split(text, "\n") {
+			perm = append(perm, len(cp.rows))
+			cp.appendLine(templateIndent + line)
+		}
+	}
+	cp.permuteRows(perm)
+	return &cp
+}
+
+// appendLine appends a row holding text across every column, marked as added
+// by the library and as belonging to the data row above it.
+func (t *Table) appendLine(text string) {
+	row := make([][]byte, len(t.headers))
+	spans := make([]int, len(t.headers))
+	for i := range row {
+		row[i] = []byte{}
+	}
+	row[0] = []byte(text)
+	spans[0] = len(t.headers)
+
+	pos := len(t.rows)
+	t.rows = append(t.rows, row)
+	t.rowKinds = append(t.rowKinds, rowData)
+	t.rowASCII = append(t.rowASCII, isPrintableASCII(row[0]))
+	t.setSpans(pos, spans)
+	t.setAux(pos)
+	t.rowBelow = growTo(t.rowBelow, pos+1)
+	t.rowBelow[pos] = true
+}
+
+// isBelow reports whether the row at pos is a line appendLine added below a
+// data row.
+func (t *Table) isBelow(pos int) bool {
+	return pos >= 0 && pos < len(t.rowBelow) && t.rowBelow[pos]
+}
@@ -0,0 +1,32 @@
+// term.go
+
+package tables
+
+import (
+	"errors"
+	"io"
+	"os"
+)
+
+// errNoTerminal is returned by features that need an interactive terminal
+// when none is available.
+var errNoTerminal = errors.New("tables: not a terminal")
+
+// fdWriter is implemented by *os.File and anything else backed by a file
+// descriptor.
+type fdWriter interface {
+	Fd() uintptr
+}
+
+// GetTerminalSize returns the size of the terminal attached to standard
+// output. ok is false when stdout is not a terminal or the size can't be
+// determined on this platform.
+func GetTerminalSize() (cols, rows int, ok bool) {
+	return terminalSize(os.Stdout.Fd())
+}
+
+// isTerminal reports whether w writes to an interactive terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(fdWriter)
+	return ok && isTerminalFd(f.Fd())
+}
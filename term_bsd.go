@@ -0,0 +1,12 @@
+// term_bsd.go
+
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+
+package tables
+
+import "syscall"
+
+const (
+	ioctlGetTermios = syscall.TIOCGETA
+	ioctlSetTermios = syscall.TIOCSETA
+)
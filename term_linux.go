@@ -0,0 +1,10 @@
+// term_linux.go
+
+package tables
+
+import "syscall"
+
+const (
+	ioctlGetTermios = syscall.TCGETS
+	ioctlSetTermios = syscall.TCSETS
+)
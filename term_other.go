@@ -0,0 +1,15 @@
+// term_other.go
+
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package tables
+
+func isTerminalFd(fd uintptr) bool { return false }
+
+func terminalSize(fd uintptr) (cols, rows int, ok bool) { return 0, 0, false }
+
+func makeRaw(fd uintptr, timeout uint8) (restore func(), err error) {
+	return nil, errNoTerminal
+}
+
+func notifyResize(fd uintptr, ch chan<- struct{}) (stop func()) { return func() {} }
@@ -0,0 +1,81 @@
+// term_unix.go
+
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package tables
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"unsafe"
+)
+
+// winsize mirrors struct winsize from <sys/ioctl.h>.
+type winsize struct {
+	Row, Col, Xpixel, Ypixel uint16
+}
+
+func ioctl(fd, req uintptr, arg unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, req, uintptr(arg)); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func isTerminalFd(fd uintptr) bool {
+	var st syscall.Termios
+	return ioctl(fd, ioctlGetTermios, unsafe.Pointer(&st)) == nil
+}
+
+func terminalSize(fd uintptr) (cols, rows int, ok bool) {
+	var ws winsize
+	if err := ioctl(fd, syscall.TIOCGWINSZ, unsafe.Pointer(&ws)); err != nil || ws.Col == 0 {
+		return 0, 0, false
+	}
+	return int(ws.Col), int(ws.Row), true
+}
+
+// makeRaw disables echo and line buffering on fd so terminal replies can be
+// read byte by byte. Reads return after timeout deciseconds without input.
+// The returned function restores the previous state.
+func makeRaw(fd uintptr, timeout uint8) (restore func(), err error) {
+	var old syscall.Termios
+	if err := ioctl(fd, ioctlGetTermios, unsafe.Pointer(&old)); err != nil {
+		return nil, errNoTerminal
+	}
+
+	raw := old
+	raw.Lflag &^= syscall.ECHO | syscall.ICANON
+	raw.Cc[syscall.VMIN] = 0
+	raw.Cc[syscall.VTIME] = timeout
+	if err := ioctl(fd, ioctlSetTermios, unsafe.Pointer(&raw)); err != nil {
+		return nil, err
+	}
+	return func() { ioctl(fd, ioctlSetTermios, unsafe.Pointer(&old)) }, nil
+}
+
+// notifyResize sends on ch, without blocking, whenever the terminal on fd is
+// resized (SIGWINCH). The returned function stops the notifications.
+func notifyResize(fd uintptr, ch chan<- struct{}) (stop func()) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGWINCH)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-sig:
+				select {
+				case ch <- struct{}{}:
+				default:
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(sig)
+		close(done)
+	}
+}
@@ -0,0 +1,70 @@
+// term_windows.go
+
+package tables
+
+import (
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+var procGetConsoleScreenBufferInfo = syscall.NewLazyDLL("kernel32.dll").NewProc("GetConsoleScreenBufferInfo")
+
+// consoleScreenBufferInfo mirrors CONSOLE_SCREEN_BUFFER_INFO.
+type consoleScreenBufferInfo struct {
+	size, cursor                   [2]int16
+	attributes                     uint16
+	left, top, right, bottom       int16
+	maxWindowSizeX, maxWindowSizeY int16
+}
+
+func isTerminalFd(fd uintptr) bool {
+	var mode uint32
+	return syscall.GetConsoleMode(syscall.Handle(fd), &mode) == nil
+}
+
+func terminalSize(fd uintptr) (cols, rows int, ok bool) {
+	var info consoleScreenBufferInfo
+	r, _, _ := procGetConsoleScreenBufferInfo.Call(fd, uintptr(unsafe.Pointer(&info)))
+	if r == 0 {
+		return 0, 0, false
+	}
+	return int(info.right-info.left) + 1, int(info.bottom-info.top) + 1, true
+}
+
+// makeRaw is not supported on Windows consoles; features that need to read
+// terminal replies report errNoTerminal instead.
+func makeRaw(fd uintptr, timeout uint8) (restore func(), err error) {
+	return nil, errNoTerminal
+}
+
+// notifyResize sends on ch, without blocking, whenever the console on fd is
+// resized. Windows has no resize signal for console programs, so the size is
+// polled four times a second. The returned function stops the notifications.
+func notifyResize(fd uintptr, ch chan<- struct{}) (stop func()) {
+	ticker := time.NewTicker(250 * time.Millisecond)
+	done := make(chan struct{})
+	go func() {
+		cols, rows, _ := terminalSize(fd)
+		for {
+			select {
+			case <-ticker.C:
+				c, r, ok := terminalSize(fd)
+				if !ok || (c == cols && r == rows) {
+					continue
+				}
+				cols, rows = c, r
+				select {
+				case ch <- struct{}{}:
+				default:
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		ticker.Stop()
+		close(done)
+	}
+}
@@ -0,0 +1,271 @@
+// theme.go
+
+package tables
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Theme holds the colors the library chooses by itself, as ANSI codes, so
+// they can stay readable on both dark and light terminal backgrounds, and a
+// table appearance that SetTheme applies in one call. Colors set explicitly,
+// with SetHeaderColor and the like, are never changed by DefaultTheme.
+type Theme struct {
+	Name string // "dark", "light" or "mono"
+
+	Added        string // Inserted text in SetDiffColumns, improvements in benchstat tables
+	Removed      string // Deleted text in SetDiffColumns, regressions in benchstat tables
+	AddedSpace   string // Background of whitespace-only insertions
+	RemovedSpace string // Background of whitespace-only deletions
+	Muted        string // Values of no interest, such as benchstat's "~"
+
+	Info    string // Used by Info
+	Success string // Used by Success
+	Warning string // Used by Warning
+	Error   string // Used by Error
+
+	// The table appearance, applied by SetTheme.
+	Border     Style  // Border style (zero = keep the table's)
+	Header     string // Header row, see SetHeaderColor
+	Body       string // Data cells without a row, column or cell color
+	StripeEven string // Even data rows, see SetRowStriping
+	StripeOdd  string // Odd data rows
+	Separators bool   // Rules between data rows, see SetRowSeparators
+}
+
+var (
+	// ThemeDark suits light text on a dark background. Its colors are the
+	// ones the library has always used.
+	ThemeDark = Theme{
+		Name:         "dark",
+		Added:        FgGreen,
+		Removed:      FgRed,
+		AddedSpace:   BgGreen,
+		RemovedSpace: BgRed,
+		Muted:        Dim,
+		Info:         FgBlue,
+		Success:      FgGreen + Bold,
+		Warning:      FgYellow,
+		Error:        FgRed + Bold,
+		Border:       StyleSingle,
+		Header:       FgCyan + Bold,
+		StripeOdd:    BgColor256(236),
+	}
+
+	// ThemeLight suits dark text on a light background, using darker shades
+	// from the 256-color palette where the standard ones wash out on white.
+	ThemeLight = Theme{
+		Name:         "light",
+		Added:        Color256(28),
+		Removed:      Color256(124),
+		AddedSpace:   BgColor256(151),
+		RemovedSpace: BgColor256(224),
+		Muted:        Dim,
+		Info:         FgBlue,
+		Success:      Color256(28) + Bold,
+		Warning:      Color256(130),
+		Error:        Color256(124) + Bold,
+		Border:       StyleSingle,
+		Header:       Color256(25) + Bold,
+		StripeOdd:    BgColor256(254),
+	}
+
+	// ThemeMonochrome uses no colors at all, only text attributes, for
+	// terminals and recordings in black and white.
+	ThemeMonochrome = Theme{
+		Name:         "mono",
+		Added:        Underline,
+		Removed:      Strike,
+		AddedSpace:   Reverse,
+		RemovedSpace: Reverse,
+		Muted:        Dim,
+		Success:      Bold,
+		Warning:      Bold,
+		Error:        Bold + Reverse,
+		Border:       StyleSingle,
+		Header:       Bold + Underline,
+	}
+)
+
+// DefaultTheme is the theme in use. It starts as ThemeLight when the
+// COLORFGBG environment variable, set by some terminals, reports a light
+// background, and ThemeDark otherwise; DetectTheme checks the terminal
+// itself.
+var DefaultTheme = themeFromEnv()
+
+// errNoBackground is returned by DetectTheme when the background color can't
+// be found out.
+var errNoBackground = errors.New("tables: terminal background color unknown")
+
+// DetectTheme finds out whether the terminal behind w has a light or dark
+// background and installs the matching theme as DefaultTheme, which it also
+// returns.
+//
+// The terminal is asked for its background color with an OSC 11 query,
+// whose reply is read from standard input. Terminals that don't support the
+// query, or when w and standard input aren't an interactive terminal, fall
+// back to COLORFGBG. If that isn't set either, an error is returned along
+// with DefaultTheme, which is left unchanged. Like CalibrateWidth, call it
+// once at startup, before other output.
+//
+// Example:
+//
+//	tables.DetectTheme(os.Stdout) // errors leave the dark theme in place
+func DetectTheme(w io.Writer) (Theme, error) {
+	light, err := queryBackground(w)
+	if err != nil {
+		var ok bool
+		if light, ok = envBackground(); !ok {
+			return DefaultTheme, err
+		}
+	}
+	if light {
+		DefaultTheme = ThemeLight
+	} else {
+		DefaultTheme = ThemeDark
+	}
+	return DefaultTheme, nil
+}
+
+// queryBackground asks the terminal behind w for its background color and
+// reports whether it is light. The OSC 11 query is followed by a device
+// attributes query, which every terminal answers, so one that ignores OSC 11
+// is found out without waiting for the timeout.
+func queryBackground(w io.Writer) (light bool, err error) {
+	if !isTerminal(w) {
+		return false, errNoTerminal
+	}
+	restore, err := makeRaw(os.Stdin.Fd(), 5)
+	if err != nil {
+		return false, err
+	}
+	defer restore()
+
+	if _, err := fmt.Fprint(w, "\033]11;?\033\\\033[c"); err != nil {
+		return false, err
+	}
+	reply, err := readUntilAttributes(os.Stdin)
+	if err != nil {
+		return false, err
+	}
+	return parseBackground(reply)
+}
+
+// readUntilAttributes reads terminal replies from r up to and including the
+// device attributes report ("ESC [ ? ... c").
+func readUntilAttributes(r io.Reader) ([]byte, error) {
+	var reply []byte
+	b := make([]byte, 1)
+	for len(reply) < 256 {
+		n, err := r.Read(b)
+		if n == 0 || err != nil {
+			return nil, errors.New("tables: terminal did not answer background color query")
+		}
+		reply = append(reply, b[0])
+		if b[0] == 'c' && bytes.Contains(reply, []byte("\033[?")) {
+			return reply, nil
+		}
+	}
+	return nil, fmt.Errorf("tables: malformed terminal reply %q", reply)
+}
+
+// parseBackground finds an OSC 11 reply ("ESC ] 11 ; rgb:RRRR/GGGG/BBBB")
+// in reply and reports whether the color is light.
+func parseBackground(reply []byte) (light bool, err error) {
+	i := bytes.Index(reply, []byte("]11;rgb:"))
+	if i < 0 {
+		return false, errNoBackground
+	}
+	rest := reply[i+len("]11;rgb:"):]
+	end := bytes.IndexAny(rest, "\a\033")
+	if end < 0 {
+		return false, fmt.Errorf("tables: malformed background color reply %q", reply)
+	}
+	parts := strings.Split(string(rest[:end]), "/")
+	if len(parts) != 3 {
+		return false, fmt.Errorf("tables: malformed background color reply %q", reply)
+	}
+
+	// Each component has 1 to 4 hex digits; scale them all to 0..1.
+	var rgb [3]float64
+	for k, p := range parts {
+		v, err := strconv.ParseUint(p, 16, 16)
+		if err != nil || len(p) == 0 || len(p) > 4 {
+			return false, fmt.Errorf("tables: malformed background color reply %q", reply)
+		}
+		rgb[k] = float64(v) / float64(uint64(1)<<(4*len(p))-1)
+	}
+	luma := 0.2126*rgb[0] + 0.7152*rgb[1] + 0.0722*rgb[2]
+	return luma > 0.5, nil
+}
+
+// envBackground reads the background color from COLORFGBG ("fg;bg" or
+// "fg;default;bg", with ANSI color numbers) and reports whether it is
+// light: white (7) or any bright color but bright black (8).
+func envBackground() (light, ok bool) {
+	v := os.Getenv("COLORFGBG")
+	if v == "" {
+		return false, false
+	}
+	bg, err := strconv.Atoi(v[strings.LastIndexByte(v, ';')+1:])
+	if err != nil || bg < 0 || bg > 15 {
+		return false, false
+	}
+	return bg == 7 || bg > 8, true
+}
+
+// themeFromEnv returns the theme COLORFGBG calls for.
+func themeFromEnv() Theme {
+	if light, ok := envBackground(); ok && light {
+		return ThemeLight
+	}
+	return ThemeDark
+}
+
+// SetTheme applies the appearance of th to the table: its border style
+// (unless zero), header, body and stripe colors, and rules between rows,
+// replacing what SetStyle, SetHeaderColor, SetRowStriping and
+// SetRowSeparators set before; later calls to those adjust it. Empty colors
+// leave the parts uncolored, and the body color gives way to row, column and
+// cell colors. The colors the table picks by itself, for diffs, group
+// subtotals, validation marks and the like, then come from th instead of
+// DefaultTheme; the package-level Info, Error and similar helpers keep using
+// DefaultTheme.
+//
+// Example:
+//
+//	t.SetTheme(tables.ThemeMonochrome)
+func (t *Table) SetTheme(th Theme) *Table {
+	if th.Border != (Style{}) {
+		t.SetStyle(th.Border)
+	}
+	t.headerColor = themeColor(th.Header)
+	t.bodyColor = themeColor(th.Body)
+	t.SetRowStriping(themeColor(th.StripeEven), themeColor(th.StripeOdd))
+	t.rowRules = th.Separators
+	t.theme = &th
+	return t
+}
+
+// palette returns the theme the table picks its own colors from.
+func (t *Table) palette() *Theme {
+	if t.theme != nil {
+		return t.theme
+	}
+	return &DefaultTheme
+}
+
+// themeColor returns a Color writing the ANSI codes of a Theme field, or nil
+// for an empty one.
+func themeColor(codes string) *Color {
+	if codes == "" {
+		return nil
+	}
+	return NewColor().WithStyle(codes)
+}
@@ -0,0 +1,99 @@
+// title.go
+
+package tables
+
+import (
+	"bytes"
+)
+
+// SetTitle sets a caption rendered above the table, centered over its full
+// width by default. Columns are widened if the title doesn't fit over them,
+// except where SetMaxWidth or a terminal width limit prevents it, in which
+// case the title is truncated. An empty title removes it.
+//
+// The title is plain text in the header's register: it goes through the same
+// escape policy as cell content and may carry its own ANSI colors. The HTML
+// export writes it as the table's <caption>.
+//
+// Example:
+//
+//	t.SetTitle("Disk usage").SetTitleBoxed(true)
+//
+//	┌─────────────────────────┐
+//	│       Disk usage        │
+//	├───────┬────────┬────────┤
+//	│ Mount │ Used   │ Free   │
+func (t *Table) SetTitle(title string) *Table {
+	if title == "" {
+		t.title = nil
+		return t
+	}
+	t.title = []byte(title)
+	if t.nfc {
+		t.title = normalizeNFC(t.title)
+	}
+	return t
+}
+
+// SetTitleAlign sets how the title is aligned over the table (default
+// AlignCenter).
+func (t *Table) SetTitleAlign(align Align) *Table {
+	t.titleAlign = align
+	return t
+}
+
+// SetTitleBoxed, when on, draws the title inside the table's border, in a
+// box spanning all columns on top of the header, instead of as a free line
+// above it.
+func (t *Table) SetTitleBoxed(on bool) *Table {
+	t.titleBoxed = on
+	return t
+}
+
+// widenForTitle returns widths grown as by widenSpan so the columns together
+// span at least the title's width. widths is returned unchanged if the title
+// already fits.
+func (t *Table) widenForTitle(widths []int) []int {
+	need := t.cellWidth(t.title, isPrintableASCII(t.title))
+	if len(widths) == 0 || need <= spanWidth(widths) {
+		return widths
+	}
+
+	widened := append([]int(nil), widths...)
+	t.widenSpan(widened, 0, len(widened), need)
+	return widened
+}
+
+// renderTitle writes the title, if set, and returns the kind of border line
+// the header should start with: "title" below a boxed title, otherwise
+// "top".
+func (t *Table) renderTitle(buf *bytes.Buffer, widths []int) string {
+	if t.title == nil || len(widths) == 0 {
+		return "top"
+	}
+
+	ascii := isPrintableASCII(t.title)
+	title := t.title
+	if !ascii {
+		title = t.display(title, -1)
+	}
+	span := spanWidth(widths)
+
+	if !t.titleBoxed {
+		// A free line over the whole table, border columns included.
+		line := t.alignCell(title, span+4, t.titleAlign, ascii)
+		buf.Write(bytes.TrimRight(line, " "))
+		buf.WriteByte('\n')
+		return "top"
+	}
+
+	t.renderBorder(buf, []int{span}, "top")
+	vertical := t.borderColor.Apply(string(t.style.Vertical))
+	buf.WriteString(vertical)
+	buf.WriteByte(' ')
+	buf.Write(t.alignCell(title, span, t.titleAlign, ascii))
+	buf.WriteByte(' ')
+	buf.WriteString(vertical)
+	buf.WriteByte('\n')
+	return "title"
+}
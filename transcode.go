@@ -0,0 +1,452 @@
+// transcode.go
+
+package tables
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Format names a table format for Transcode.
+type Format int
+
+const (
+	FormatCSV      Format = iota // RFC 4180 CSV, as ToCSV writes and NewFromCSV reads
+	FormatTSV                    // Tab-separated values, as WriteTSVTo writes
+	FormatNDJSON                 // One flat JSON object per line (JSON Lines)
+	FormatText                   // Box-drawn text, as String renders; output only
+	FormatMarkdown               // GFM pipe table, as Markdown renders; output only
+)
+
+var formatNames = [...]string{"CSV", "TSV", "NDJSON", "text", "Markdown"}
+
+// String returns the name of the format, such as "CSV".
+func (f Format) String() string {
+	if f < 0 || int(f) >= len(formatNames) {
+		return "Format(" + strconv.Itoa(int(f)) + ")"
+	}
+	return formatNames[f]
+}
+
+// transcodeConfig holds the settings applied by TranscodeOption values.
+type transcodeConfig struct {
+	csv    []CSVOption
+	sample int
+	style  Style
+}
+
+// TranscodeOption configures Transcode.
+type TranscodeOption func(*transcodeConfig)
+
+// TranscodeCSV applies CSV options to reading CSV and TSV input. The options
+// for the delimiter, comments, quoting, spaces and headers are honored;
+// CSVSniffTypes, CSVDetectHeader and CSVSchema, which need the whole input,
+// are ignored.
+func TranscodeCSV(opts ...CSVOption) TranscodeOption {
+	return func(c *transcodeConfig) { c.csv = append(c.csv, opts...) }
+}
+
+// TranscodeSample sets how many rows text output reads before it fixes the
+// column widths (default 100). Larger samples fit the data better but hold
+// more of it in memory before the first line is written.
+func TranscodeSample(rows int) TranscodeOption {
+	return func(c *transcodeConfig) { c.sample = max(rows, 1) }
+}
+
+// TranscodeStyle sets the border style of text output (default StyleSingle).
+func TranscodeStyle(s Style) TranscodeOption {
+	return func(c *transcodeConfig) { c.style = s }
+}
+
+// Transcode converts a table from one format to another, row by row, without
+// building a Table: memory use stays flat however long the input is, so it
+// suits large exports and pipelines such as
+//
+//	tables.Transcode(os.Stdin, os.Stdout, tables.FormatCSV, tables.FormatText)
+//
+// Input can be CSV, TSV or NDJSON, output any Format. The first CSV or TSV
+// record is the header unless TranscodeCSV says otherwise; NDJSON headers are
+// the keys of the first object, in order, and keys that first appear later
+// are dropped. Values are passed through as text, numbers as written in the
+// input; NDJSON output values are strings. Every output but text has ANSI
+// sequences stripped.
+//
+// Text output can't know the widest value ahead of time, so column widths
+// are fixed from the header and the first rows (see TranscodeSample); longer
+// values further on are truncated, as with LockWidths. Output is buffered and
+// flushed as it goes and once more at the end.
+func Transcode(r io.Reader, w io.Writer, in, out Format, opts ...TranscodeOption) error {
+	cfg := transcodeConfig{sample: 100, style: StyleSingle}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	read, err := newRecordReader(r, in, &cfg)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	sink, err := newRecordSink(bw, out, &cfg)
+	if err != nil {
+		return err
+	}
+
+	headers, err := read()
+	if errors.Is(err, io.EOF) {
+		return nil // no input, no table
+	}
+	if err != nil {
+		return err
+	}
+	headers = append([]string(nil), headers...)
+	if err := sink.header(headers); err != nil {
+		return err
+	}
+	row := make([]string, len(headers))
+	for {
+		rec, err := read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		// Pad or cut to the header count, as AddRow does.
+		clear(row)
+		copy(row, rec)
+		if err := sink.row(row); err != nil {
+			return err
+		}
+	}
+	if err := sink.close(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// newRecordReader returns a function reading the header and then one record
+// per call from r, io.EOF at the end. Records may be reused between calls.
+func newRecordReader(r io.Reader, in Format, cfg *transcodeConfig) (func() ([]string, error), error) {
+	switch in {
+	case FormatCSV, FormatTSV:
+		return newCSVRecordReader(r, in, cfg), nil
+	case FormatNDJSON:
+		return newNDJSONRecordReader(r), nil
+	}
+	return nil, fmt.Errorf("tables: can't transcode from %v", in)
+}
+
+func newCSVRecordReader(r io.Reader, in Format, cfg *transcodeConfig) func() ([]string, error) {
+	csvCfg := csvConfig{delimiter: ','}
+	if in == FormatTSV {
+		csvCfg.delimiter = '\t'
+	}
+	for _, opt := range cfg.csv {
+		opt(&csvCfg)
+	}
+
+	var next func() ([]string, error)
+	if in == FormatTSV {
+		// TSV has no quoting: split lines on the delimiter, as
+		// NewFromDelimited does.
+		sc := bufio.NewScanner(r)
+		sc.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+		next = func() ([]string, error) {
+			for sc.Scan() {
+				line := sc.Text()
+				if strings.TrimSpace(line) == "" ||
+					csvCfg.comment != 0 && strings.HasPrefix(line, string(csvCfg.comment)) {
+					continue
+				}
+				return splitDelimited(line, csvCfg.delimiter, -1), nil
+			}
+			if err := sc.Err(); err != nil {
+				return nil, fmt.Errorf("tables: reading TSV: %w", err)
+			}
+			return nil, io.EOF
+		}
+	} else {
+		cr := csv.NewReader(r)
+		cr.Comma = csvCfg.delimiter
+		cr.Comment = csvCfg.comment
+		cr.LazyQuotes = csvCfg.lazyQuotes
+		cr.TrimLeadingSpace = csvCfg.trimSpace
+		cr.FieldsPerRecord = -1
+		cr.ReuseRecord = true
+		next = func() ([]string, error) {
+			rec, err := cr.Read()
+			if err != nil && !errors.Is(err, io.EOF) {
+				return nil, fmt.Errorf("tables: reading CSV: %w", err)
+			}
+			return rec, err
+		}
+	}
+
+	// The header comes first: CSVHeaders, numbered names with CSVSkipHeader,
+	// or else the first record.
+	started := false
+	return func() ([]string, error) {
+		if started {
+			return next()
+		}
+		started = true
+		if csvCfg.headers != nil && !csvCfg.skipHeader {
+			return csvCfg.headers, nil
+		}
+		first, err := next()
+		if err != nil {
+			return nil, err
+		}
+		if !csvCfg.skipHeader {
+			return first, nil
+		}
+		headers := csvCfg.headers
+		if headers == nil {
+			headers = numberedHeaders(len(first))
+		}
+		return headers, nil
+	}
+}
+
+func newNDJSONRecordReader(r io.Reader) func() ([]string, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	var keys []string
+	col := make(map[string]int)
+	var rec []string
+	pending := false // rec holds the first object, read with the headers
+	return func() ([]string, error) {
+		if pending {
+			pending = false
+			return rec, nil
+		}
+		for sc.Scan() {
+			line := bytes.TrimSpace(sc.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			dec := json.NewDecoder(bytes.NewReader(line))
+			dec.UseNumber()
+
+			if keys == nil {
+				// The first object gives the headers as well as a row.
+				err := decodeObject(dec, func(key string, cell []byte) {
+					if _, dup := col[key]; !dup {
+						col[key] = len(keys)
+						keys = append(keys, key)
+						rec = append(rec, string(cell))
+					}
+				})
+				if err != nil {
+					return nil, err
+				}
+				pending = true
+				return keys, nil
+			}
+
+			clear(rec)
+			err := decodeObject(dec, func(key string, cell []byte) {
+				if i, ok := col[key]; ok {
+					rec[i] = string(cell)
+				}
+			})
+			if err != nil {
+				return nil, err
+			}
+			return rec, nil
+		}
+		if err := sc.Err(); err != nil {
+			return nil, fmt.Errorf("tables: reading NDJSON: %w", err)
+		}
+		return nil, io.EOF
+	}
+}
+
+// recordSink writes records in one output format.
+type recordSink interface {
+	header(cells []string) error
+	row(cells []string) error
+	close() error
+}
+
+func newRecordSink(w *bufio.Writer, out Format, cfg *transcodeConfig) (recordSink, error) {
+	switch out {
+	case FormatCSV:
+		return &lineSink{w: w, sep: ",", field: func(s string) string { return csvField(StripANSI(s)) }}, nil
+	case FormatTSV:
+		return &lineSink{w: w, sep: "\t", field: func(s string) string { return tsvEscaper.Replace(StripANSI(s)) }}, nil
+	case FormatNDJSON:
+		return &ndjsonSink{w: w}, nil
+	case FormatText:
+		return &textSink{w: w, sample: cfg.sample, style: cfg.style}, nil
+	case FormatMarkdown:
+		return &markdownSink{w: w}, nil
+	}
+	return nil, fmt.Errorf("tables: can't transcode to %v", out)
+}
+
+// lineSink writes CSV and TSV: one line per record, fields converted by
+// field and joined by sep.
+type lineSink struct {
+	w     *bufio.Writer
+	sep   string
+	field func(string) string
+}
+
+func (s *lineSink) header(cells []string) error { return s.row(cells) }
+
+func (s *lineSink) row(cells []string) error {
+	for i, c := range cells {
+		if i > 0 {
+			s.w.WriteString(s.sep)
+		}
+		s.w.WriteString(s.field(c))
+	}
+	_, err := s.w.WriteString("\n")
+	return err
+}
+
+func (s *lineSink) close() error { return nil }
+
+// ndjsonSink writes one JSON object per record, keyed by header.
+type ndjsonSink struct {
+	w    *bufio.Writer
+	keys []string
+	buf  bytes.Buffer
+}
+
+func (s *ndjsonSink) header(cells []string) error {
+	s.keys = cells
+	return nil
+}
+
+func (s *ndjsonSink) row(cells []string) error {
+	s.buf.Reset()
+	s.buf.WriteByte('{')
+	for i, key := range s.keys {
+		if i > 0 {
+			s.buf.WriteByte(',')
+		}
+		writeJSONString(&s.buf, StripANSI(key))
+		s.buf.WriteByte(':')
+		writeJSONString(&s.buf, StripANSI(cells[i]))
+	}
+	s.buf.WriteString("}\n")
+	_, err := s.w.Write(s.buf.Bytes())
+	return err
+}
+
+func (s *ndjsonSink) close() error { return nil }
+
+// markdownSink writes a GFM pipe table. Cells aren't padded to a common
+// width, which Markdown doesn't need and a stream can't know.
+type markdownSink struct {
+	w *bufio.Writer
+}
+
+func (s *markdownSink) header(cells []string) error {
+	s.row(cells)
+	s.w.WriteString("|")
+	for range cells {
+		s.w.WriteString(" --- |")
+	}
+	_, err := s.w.WriteString("\n")
+	return err
+}
+
+func (s *markdownSink) row(cells []string) error {
+	s.w.WriteString("|")
+	for _, c := range cells {
+		s.w.WriteString(" ")
+		s.w.WriteString(mdCell([]byte(c), 0))
+		s.w.WriteString(" |")
+	}
+	_, err := s.w.WriteString("\n")
+	return err
+}
+
+func (s *markdownSink) close() error { return nil }
+
+// textSink writes a box-drawn table. The first rows are held back to size
+// the columns, which are then fixed for the rest of the stream.
+type textSink struct {
+	w      *bufio.Writer
+	sample int
+	style  Style
+	t      *Table   // headers and sampled rows, for measuring and rendering
+	widths []int    // nil until the sample is complete
+	rows   int      // data rows written
+	cells  [][]byte // scratch row
+	buf    bytes.Buffer
+}
+
+func (s *textSink) header(cells []string) error {
+	s.t = NewFromStrings(cells...).SetStyle(s.style)
+	return s.t.Err()
+}
+
+func (s *textSink) row(cells []string) error {
+	if s.widths == nil {
+		row := make([]any, len(cells))
+		for i, c := range cells {
+			row[i] = c
+		}
+		s.t.AddRow(row...)
+		if len(s.t.rows) >= s.sample {
+			return s.start()
+		}
+		return nil
+	}
+
+	s.cells = s.cells[:0]
+	for _, c := range cells {
+		s.cells = append(s.cells, []byte(c))
+	}
+	return s.write(s.cells, rowIsASCII(s.cells))
+}
+
+// start fixes the widths and writes the header and the sampled rows.
+func (s *textSink) start() error {
+	s.widths = s.t.columnWidths()
+	s.buf.Reset()
+	s.t.renderBorder(&s.buf, s.widths, "top")
+	s.t.renderRow(&s.buf, s.t.headers, s.widths, -1, rowIsASCII(s.t.headers), nil)
+	s.t.renderBorder(&s.buf, s.widths, "middle")
+	if _, err := s.w.Write(s.buf.Bytes()); err != nil {
+		return err
+	}
+	for pos, row := range s.t.rows {
+		if err := s.write(row, s.t.rowASCII[pos]); err != nil {
+			return err
+		}
+	}
+	s.t.rows, s.t.rowKinds, s.t.rowASCII = nil, nil, nil
+	return nil
+}
+
+func (s *textSink) write(cells [][]byte, ascii bool) error {
+	s.buf.Reset()
+	s.t.renderRow(&s.buf, cells, s.widths, s.rows, ascii, nil)
+	s.rows++
+	_, err := s.w.Write(s.buf.Bytes())
+	return err
+}
+
+func (s *textSink) close() error {
+	if s.widths == nil {
+		if err := s.start(); err != nil {
+			return err
+		}
+	}
+	s.buf.Reset()
+	s.t.renderBorder(&s.buf, s.widths, "bottom")
+	_, err := s.w.Write(s.buf.Bytes())
+	return err
+}
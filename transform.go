@@ -0,0 +1,76 @@
+// transform.go
+
+package tables
+
+import (
+	"slices"
+)
+
+// SetCellTransformer rewrites the cells of column col as they are drawn, for
+// conditional formatting such as negative numbers in red or values over a
+// threshold highlighted, without changing the stored data:
+//
+//	red := tables.NewColor().WithFg(tables.FgRed)
+//	t.SetCellTransformer(2, func(row int, value []byte) []byte {
+//	    if bytes.HasPrefix(value, []byte("-")) {
+//	        return []byte(red.Apply(string(value)))
+//	    }
+//	    return value
+//	})
+//
+// fn gets each data row's cell, ANSI sequences included, and returns the text
+// to show in its place; it must not modify value, but may return it as is.
+// row counts the data rows as drawn, from 0, after SetFilter, sorting and
+// GroupBy, the way SetAutoIndex numbers them. Rows added with AddSpannedRow,
+// AddSection banners and group subtotals are left alone, as is the header.
+// Pass nil to stop transforming the column.
+//
+// Only the rendered output changes: SetFilter, GroupBy, SetColumnAggregate,
+// SetRowTemplate and the exporters see the values as stored.
+func (t *Table) SetCellTransformer(col int, fn func(row int, value []byte) []byte) *Table {
+	if !t.checkColumn(col) {
+		return t
+	}
+	if fn == nil {
+		delete(t.transformers, col)
+		if len(t.transformers) == 0 {
+			t.transformers = nil
+		}
+		return t
+	}
+	if t.transformers == nil {
+		t.transformers = make(map[int]func(int, []byte) []byte)
+	}
+	t.transformers[col] = fn
+	return t
+}
+
+// transformed returns t itself, or with SetCellTransformer a copy with the
+// cells of the transformed columns replaced.
+func (t *Table) transformed() *Table {
+	if t.transformers == nil {
+		return t
+	}
+
+	cp := *t
+	cp.transformers = nil
+	cp.rows = slices.Clone(t.rows)
+	cp.rowASCII = slices.Clone(t.rowASCII)
+
+	row := 0
+	for pos, kind := range t.rowKinds {
+		if kind != rowData || t.spansAt(pos) != nil || t.isAux(pos) {
+			continue
+		}
+		cells := slices.Clone(t.rows[pos])
+		for col, fn := range t.transformers {
+			if col < len(cells) {
+				cells[col] = fn(row, cells[col])
+			}
+		}
+		cp.rows[pos] = cells
+		cp.rowASCII[pos] = rowIsASCII(cells)
+		row++
+	}
+	return &cp
+}
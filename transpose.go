@@ -0,0 +1,87 @@
+// transpose.go
+
+package tables
+
+import (
+	"strconv"
+)
+
+// Transpose returns a new table with the rows and columns of this one
+// swapped: the headers become the first column, and each data row a column
+// headed by its number, counting from 1. It suits wide tables holding a
+// single record, which read better as a list of fields:
+//
+//	┌──────────┬──────────┐
+//	│          │ 1        │
+//	├──────────┼──────────┤
+//	│ Name     │ web-7f9c │
+//	│ Status   │ Running  │
+//	│ Node     │ worker-2 │
+//	│ Restarts │ 0        │
+//	└──────────┴──────────┘
+//
+// Colors move with their cells: row colors become column colors and the
+// other way round, and the header color colors the first column. Only data
+// rows SetFilter keeps are included; separators, section banners and the
+// footer are left out, and cells spanning columns contribute only their
+// first cell. The new table has the border style, width function and escape
+// policy of this one. Rename its headers with SetHeader.
+//
+// Example:
+//
+//	t.Transpose().SetHeader(1, "Value").Print()
+func (t *Table) Transpose() *Table {
+	src := t.warm().filtered()
+
+	var positions []int
+	for pos, kind := range src.rowKinds {
+		if kind == rowData && !src.isAux(pos) {
+			positions = append(positions, pos)
+		}
+	}
+
+	headers := make([]string, 1+len(positions))
+	for i := range positions {
+		headers[1+i] = strconv.Itoa(i + 1)
+	}
+	tr := t.derive(headers...)
+
+	for col, header := range src.headers {
+		row := make([][]byte, len(headers))
+		row[0] = header
+		for i, pos := range positions {
+			row[1+i] = cellAt(src.rows[pos], col)
+			if row[1+i] == nil {
+				row[1+i] = []byte{}
+			}
+		}
+		tr.appendRow(row)
+	}
+
+	// Data row index in src of each position, for its colors.
+	index := make(map[int]int, len(positions))
+	n := 0
+	for pos, kind := range src.rowKinds {
+		if kind == rowData {
+			index[pos] = n
+			n++
+		}
+	}
+	if src.headerColor != nil {
+		tr.SetColumnColor(0, src.headerColor)
+	}
+	for i, pos := range positions {
+		if c, ok := src.rowColors[index[pos]]; ok {
+			tr.SetColumnColor(1+i, c)
+		}
+		for col := range src.headers {
+			if c, ok := src.cellColors[rowcol{index[pos], col}]; ok {
+				tr.SetCellColor(col, 1+i, c)
+			}
+		}
+	}
+	for col, c := range src.colColors {
+		tr.SetRowColor(col, c)
+	}
+	return tr
+}
@@ -0,0 +1,140 @@
+// tree.go
+
+package tables
+
+import (
+	"slices"
+)
+
+// Guides drawn in front of the first cell of rows added with AddChildRow.
+var (
+	treeBranch = []byte("├── ")
+	treeLast   = []byte("└── ")
+	treePipe   = []byte("│   ")
+	treeBlank  = []byte("    ")
+)
+
+// AddChildRow adds a row nested under data row parentIndex, counting data
+// rows as SetCell does, for file trees, dependency trees and org charts.
+// When the table is rendered each row is drawn below its parent, after any
+// children added before it, and the first cell of a nested row gets
+// tree guides in front:
+//
+//	t := tables.NewFromStrings("Path", "Size").
+//	    AddRow("src", "12K").
+//	    AddChildRow(0, "main.go", "4K").
+//	    AddChildRow(0, "util", "8K").
+//	    AddChildRow(2, "strings.go", "8K")
+//
+//	│ src                │ 12K  │
+//	│ ├── main.go        │ 4K   │
+//	│ └── util           │ 8K   │
+//	│     └── strings.go │ 8K   │
+//
+// Children may be nested under children to any depth. SortBy orders the
+// rows among their siblings, and a row SetFilter keeps whose parent it
+// doesn't moves up to the top level. GroupBy lays the rows out by group
+// instead. Only the rendered output is indented: Rows and the exporters see
+// the values as stored. A parent that isn't a data row records
+// ErrRowOutOfRange and leaves the table unchanged.
+func (t *Table) AddChildRow(parentIndex int, values ...interface{}) *Table {
+	parent := t.rowPos(parentIndex)
+	if parent < 0 || len(values) == 0 {
+		return t
+	}
+
+	row := make([][]byte, len(t.headers))
+	t.fillRow(row, values)
+	t.appendRow(row)
+	pos := len(t.rows) - 1
+	t.rowParent = growTo(t.rowParent, pos+1)
+	t.rowParent[pos] = parent + 1
+	return t
+}
+
+// parentOf returns the position in rows of the parent of the row at pos, or
+// -1 for a row at the top level.
+func (t *Table) parentOf(pos int) int {
+	if pos < 0 || pos >= len(t.rowParent) {
+		return -1
+	}
+	return t.rowParent[pos] - 1
+}
+
+// permuteParents reorders parents like permuteSpans, pointing each entry at
+// the new position of the parent. Parents perm leaves out make their
+// children top-level rows.
+func permuteParents(parents, perm []int) []int {
+	if parents == nil {
+		return nil
+	}
+	moved := make(map[int]int, len(perm)) // Old position to new
+	for i, p := range perm {
+		moved[p] = i
+	}
+	out := make([]int, len(perm))
+	for i, p := range perm {
+		if p >= len(parents) || parents[p] == 0 {
+			continue
+		}
+		if np, ok := moved[parents[p]-1]; ok {
+			out[i] = np + 1
+		}
+	}
+	return out
+}
+
+// treed returns t itself, or after AddChildRow a copy with each row below
+// its parent and tree guides in front of the first cell of nested rows.
+func (t *Table) treed() *Table {
+	if t.rowParent == nil {
+		return t
+	}
+
+	children := make(map[int][]int) // Positions of each row's children, in order
+	for pos := range t.rows {
+		if p := t.parentOf(pos); p >= 0 {
+			children[p] = append(children[p], pos)
+		}
+	}
+
+	// Every top-level position in order, each data row followed by its
+	// descendants depth first, with the guides each of them gets.
+	perm := make([]int, 0, len(t.rows))
+	guides := make(map[int][]byte)
+	var walk func(pos int, indent []byte)
+	walk = func(pos int, indent []byte) {
+		kids := children[pos]
+		for i, kid := range kids {
+			perm = append(perm, kid)
+			branch, next := treeBranch, treePipe
+			if i == len(kids)-1 {
+				branch, next = treeLast, treeBlank
+			}
+			guides[kid] = append(slices.Clip(indent), branch...)
+			walk(kid, append(slices.Clip(indent), next...))
+		}
+	}
+	for pos := range t.rows {
+		if t.parentOf(pos) >= 0 {
+			continue
+		}
+		perm = append(perm, pos)
+		walk(pos, nil)
+	}
+
+	cp := *t
+	cp.rowParent = nil
+	cp.rows = slices.Clone(t.rows)
+	cp.rowASCII = slices.Clone(t.rowASCII)
+	for pos, guide := range guides {
+		if len(t.rows[pos]) == 0 {
+			continue
+		}
+		cp.rows[pos] = slices.Clone(t.rows[pos])
+		cp.rows[pos][0] = append(guide, t.rows[pos][0]...)
+		cp.rowASCII[pos] = false
+	}
+	cp.permuteRows(perm)
+	return &cp
+}
@@ -0,0 +1,174 @@
+// validate.go
+
+package tables
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ValidationError is a cell that failed its column's SetColumnValidator
+// check, as listed by ValidationErrors.
+type ValidationError struct {
+	Row   int    // Data row index, as used by SetCell
+	Col   int    // Column index
+	Value string // The stored value
+	Err   error  // What the validator returned
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("row %d, column %d: %v", e.Row, e.Col, e.Err)
+}
+
+// Unwrap returns the validator's error, for errors.Is and errors.As.
+func (e ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// SetColumnValidator checks every value of column col with valid when the
+// table is rendered, turning it into a data-quality report: cells valid
+// rejects are shown with a mark in front and in the error color (see
+// SetValidationMark), and ValidationErrors lists them. valid is called with
+// the stored cell, ANSI sequences included, and must not modify it. Rows
+// added with AddSpannedRow and AddSection banners aren't checked. Pass nil to
+// stop checking the column.
+//
+// Only the rendered output is marked: SetFilter, GroupBy, SetColumnAggregate
+// and the exporters see the values as stored.
+//
+// Example:
+//
+//	t.SetColumnValidator(2, func(cell []byte) error {
+//	    if _, err := strconv.Atoi(string(cell)); err != nil {
+//	        return errors.New("not a number")
+//	    }
+//	    return nil
+//	})
+//
+//	│ web-1 │ eu │ 42    │
+//	│ web-2 │ us │ × n/a │
+func (t *Table) SetColumnValidator(col int, valid func(cell []byte) error) *Table {
+	if !t.checkColumn(col) {
+		return t
+	}
+	if valid == nil {
+		delete(t.validators, col)
+		if len(t.validators) == 0 {
+			t.validators = nil
+		}
+		return t
+	}
+	if t.validators == nil {
+		t.validators = make(map[int]func([]byte) error)
+	}
+	t.validators[col] = valid
+	return t
+}
+
+// SetValidationMark sets how cells failing SetColumnValidator are shown: with
+// mark in front of the value (default "× ") and colored c, over any color of
+// their own. An empty mark shows the value alone; a nil c uses the theme's
+// error color, and NewColor() leaves the cells uncolored.
+func (t *Table) SetValidationMark(mark string, c *Color) *Table {
+	t.invalidMark = []byte(mark)
+	t.invalidColor = c
+	return t
+}
+
+// ValidationErrors runs the SetColumnValidator checks over every data row,
+// SetFilter notwithstanding, and returns the cells that fail, by row and
+// then column. It returns nil when every cell passes.
+func (t *Table) ValidationErrors() []ValidationError {
+	w := t.warm()
+	var errs []ValidationError
+	w.eachInvalid(func(pos, row, col int, err error) {
+		errs = append(errs, ValidationError{Row: row, Col: col, Value: string(cellAt(w.rows[pos], col)), Err: err})
+	})
+	return errs
+}
+
+// eachInvalid calls fn with the position, data row index and column of each
+// cell failing its column's validator, in order.
+func (t *Table) eachInvalid(fn func(pos, row, col int, err error)) {
+	cols := slices.Sorted(maps.Keys(t.validators))
+	row := 0
+	for pos, kind := range t.rowKinds {
+		if kind != rowData {
+			continue
+		}
+		if t.spansAt(pos) == nil && !t.isAux(pos) {
+			for _, col := range cols {
+				if err := t.validators[col](cellAt(t.rows[pos], col)); err != nil {
+					fn(pos, row, col, err)
+				}
+			}
+		}
+		row++
+	}
+}
+
+// validated returns t itself, or with SetColumnValidator a copy coloring the
+// failing cells with a color of their own, by which flagged finds them once
+// grouping and aggregates have seen the values unmarked.
+func (t *Table) validated() *Table {
+	if t.validators == nil {
+		return t
+	}
+
+	c := t.invalidColor
+	if c == nil {
+		c = NewColor().WithStyle(t.palette().Error)
+	}
+	flag := *c // a copy, so only these cells point at it
+
+	cp := *t
+	cp.validators = nil
+	cp.flag = &flag
+	cp.cellColors = maps.Clone(t.cellColors)
+	t.eachInvalid(func(_, row, col int, _ error) {
+		if cp.cellColors == nil {
+			cp.cellColors = make(map[rowcol]*Color)
+		}
+		cp.cellColors[rowcol{row, col}] = cp.flag
+	})
+	return &cp
+}
+
+// flagged returns t itself, or after validated a copy with the validation
+// mark in front of the cells it colored.
+func (t *Table) flagged() *Table {
+	if t.flag == nil {
+		return t
+	}
+
+	cp := *t
+	cp.flag = nil
+	if len(t.invalidMark) == 0 {
+		return &cp
+	}
+	cp.rows = slices.Clone(t.rows)
+	cp.rowASCII = slices.Clone(t.rowASCII)
+	markASCII := isPrintableASCII(t.invalidMark)
+
+	row := 0
+	for pos, kind := range t.rowKinds {
+		if kind != rowData {
+			continue
+		}
+		cloned := false
+		for col := range t.rows[pos] {
+			if t.cellColors[rowcol{row, col}] != t.flag {
+				continue
+			}
+			if !cloned {
+				cp.rows[pos] = slices.Clone(t.rows[pos])
+				cloned = true
+			}
+			cp.rows[pos][col] = append(slices.Clip(t.invalidMark), t.rows[pos][col]...)
+			cp.rowASCII[pos] = cp.rowASCII[pos] && markASCII
+		}
+		row++
+	}
+	return &cp
+}
@@ -0,0 +1,91 @@
+// vertical.go
+
+package tables
+
+import (
+	"os"
+	"strconv"
+)
+
+// StringVertical renders the table one record at a time, like MySQL's \G:
+// each data row becomes a block of field/value lines under a banner with its
+// number, counting from 1. It suits tables too wide for the terminal, whose
+// rows would otherwise wrap or be cut down to a few columns:
+//
+//	┌────────┬──────────┐
+//	│ Field  │ Value    │
+//	├────────┴──────────┤
+//	│ Row 1             │
+//	├────────┬──────────┤
+//	│ Name   │ web-7f9c │
+//	│ Status │ Running  │
+//	├────────┴──────────┤
+//	│ Row 2             │
+//
+// The blocks are drawn in the border style, width function and escape policy
+// of the table, under its title. Values are shown as in the table: SetMaxWidth
+// cuts them, unless the column wraps, and time zones and relative times
+// apply. Colors follow the cells, with the header color on the field names
+// and the section color on the banners. Only data rows SetFilter keeps are
+// included; separators, section banners and the footer are left out.
+func (t *Table) StringVertical() string {
+	return t.vertical().String()
+}
+
+// PrintVertical prints the table to stdout one record at a time, as
+// StringVertical renders it, uncolored unless stdout is a terminal (see
+// SetColorMode).
+func (t *Table) PrintVertical() {
+	os.Stdout.Write(t.forWriter(os.Stdout, []byte(t.StringVertical())))
+}
+
+// vertical returns a two-column table holding the records of t as blocks of
+// field/value rows.
+func (t *Table) vertical() *Table {
+	src := t.warm().filtered()
+	v := t.derive("Field", "Value")
+	v.title, v.titleAlign, v.titleBoxed = t.title, t.titleAlign, t.titleBoxed
+	v.sectionColor = t.sectionColor
+
+	idx, n, r := 0, 0, 0 // data row index in src, records so far, data rows in v
+	for pos, kind := range src.rowKinds {
+		if kind != rowData {
+			continue
+		}
+		if src.isAux(pos) {
+			idx++
+			continue
+		}
+		row := src.rows[pos]
+		n++
+		if n > 1 {
+			v.AddSeparator()
+		}
+		v.AddSection("Row " + strconv.Itoa(n))
+		r++
+
+		for col, header := range src.headers {
+			cell := cellAt(row, col)
+			if cell == nil {
+				cell = []byte{}
+			}
+			if src.showsTimes() {
+				cell = src.timeCell(cell, col)
+			}
+			if w := src.maxWidths[col]; w > 0 && !src.wrapCols[col] && src.cellWidth(cell, false) > w {
+				cell = src.truncateWithANSI(cell, w)
+			}
+
+			v.appendRow([][]byte{header, cell})
+			if src.headerColor != nil {
+				v.SetCellColor(r, 0, src.headerColor)
+			}
+			if c := src.cellColor(idx, col, cellAt(row, col)); c != nil {
+				v.SetCellColor(r, 1, c)
+			}
+			r++
+		}
+		idx++
+	}
+	return v
+}
@@ -170,6 +170,12 @@ func TruncateToWidth(s string, maxWidth int) string {
 
 // TruncateToWidthBytes truncates byte slice to fit within display width
 func TruncateToWidthBytes(b []byte, maxWidth int) []byte {
+	return truncateToWidthBytesCustom(b, maxWidth, RuneWidth)
+}
+
+// truncateToWidthBytesCustom is TruncateToWidthBytes measuring with
+// widthFunc.
+func truncateToWidthBytesCustom(b []byte, maxWidth int, widthFunc WidthFunc) []byte {
 	if maxWidth <= 0 {
 		return []byte{}
 	}
@@ -179,7 +185,7 @@ func TruncateToWidthBytes(b []byte, maxWidth int) []byte {
 
 	for len(b) > 0 {
 		r, size := utf8.DecodeRune(b)
-		runeWidth := RuneWidth(r)
+		runeWidth := widthFunc(r)
 
 		if width+runeWidth > maxWidth {
 			break
@@ -297,6 +303,99 @@ type WidthFunc func(rune) int
 // DefaultWidthFunc is the default width calculation function
 var DefaultWidthFunc WidthFunc = RuneWidth
 
+// --- WidthFunc presets ----------------------------------------------------
+
+// Ready-made width functions for SetWidthFunc. Each one starts from RuneWidth
+// and adjusts the classes of characters terminals disagree on.
+var (
+	// WidthWCWidth follows the classic POSIX wcwidth() behavior found in most
+	// Linux terminals: only East Asian Wide/Fullwidth characters and emoji are
+	// double width. Ambiguous symbols (①, ■, ★, ✓) are single width and
+	// Hangul medial/final jamo are zero width.
+	WidthWCWidth WidthFunc = wcwidth
+
+	// WidthEastAsian treats East Asian Ambiguous characters (Greek, Cyrillic,
+	// box drawing, ±, °, §, arrows, ...) as double width, matching terminals
+	// running in CJK legacy locales.
+	WidthEastAsian WidthFunc = eastAsianWidth
+
+	// WidthMonospacedEmoji1 renders emoji and wide symbols as single width,
+	// as many CI log viewers and older terminals do. CJK stays double width.
+	WidthMonospacedEmoji1 WidthFunc = monospacedEmoji1
+)
+
+// ambiguousRanges lists the most common East Asian Ambiguous ranges. Like
+// wideRanges it is a compact heuristic rather than the full Unicode table.
+var ambiguousRanges = []unicodeRange{
+	{0x00A1, 0x00A1, 2}, {0x00A4, 0x00A4, 2}, {0x00A7, 0x00A8, 2},
+	{0x00AA, 0x00AA, 2}, {0x00AD, 0x00AE, 2}, {0x00B0, 0x00B4, 2},
+	{0x00B6, 0x00BA, 2}, {0x00BC, 0x00BF, 2}, {0x00C6, 0x00C6, 2},
+	{0x00D0, 0x00D0, 2}, {0x00D7, 0x00D8, 2}, {0x00DE, 0x00E1, 2},
+	{0x00E6, 0x00E6, 2}, {0x00E8, 0x00EA, 2}, {0x00EC, 0x00ED, 2},
+	{0x00F0, 0x00F0, 2}, {0x00F2, 0x00F3, 2}, {0x00F7, 0x00FA, 2},
+	{0x00FC, 0x00FC, 2}, {0x00FE, 0x00FE, 2},
+	{0x0391, 0x03A9, 2}, // Greek capitals
+	{0x03B1, 0x03C9, 2}, // Greek small letters
+	// Cyrillic
+	{0x0401, 0x0401, 2}, {0x0410, 0x044F, 2}, {0x0451, 0x0451, 2},
+	{0x2010, 0x2010, 2}, {0x2013, 0x2016, 2}, {0x2018, 0x2019, 2},
+	{0x201C, 0x201D, 2}, {0x2020, 0x2022, 2}, {0x2024, 0x2027, 2},
+	{0x2030, 0x2030, 2}, {0x2032, 0x2033, 2}, {0x2035, 0x2035, 2},
+	{0x203B, 0x203B, 2}, {0x203E, 0x203E, 2},
+	{0x2103, 0x2103, 2}, {0x2109, 0x2109, 2}, {0x2113, 0x2113, 2},
+	{0x2116, 0x2116, 2}, {0x2121, 0x2122, 2}, {0x2126, 0x2126, 2},
+	{0x212B, 0x212B, 2}, {0x2153, 0x2154, 2}, {0x215B, 0x215E, 2},
+	// Roman numerals and arrows
+	{0x2160, 0x216B, 2}, {0x2170, 0x2179, 2},
+	{0x2190, 0x2199, 2}, {0x21D2, 0x21D2, 2}, {0x21D4, 0x21D4, 2},
+	{0x2200, 0x22FF, 2}, // Mathematical Operators
+	{0x2500, 0x257F, 2}, // Box Drawing
+	{0x2580, 0x259F, 2}, // Block Elements
+	{0xE000, 0xF8FF, 2}, // Private Use Area
+}
+
+func wcwidth(r rune) int {
+	if r >= 0x1160 && r <= 0x11FF {
+		return 0 // Hangul medial vowels and final consonants combine
+	}
+	w := RuneWidth(r)
+	if w == 2 && classifyWide(r) == wideSymbol {
+		return 1
+	}
+	return w
+}
+
+func eastAsianWidth(r rune) int {
+	for _, rang := range ambiguousRanges {
+		if r >= rang.start && r <= rang.end {
+			return rang.width
+		}
+	}
+	return RuneWidth(r)
+}
+
+func monospacedEmoji1(r rune) int {
+	w := RuneWidth(r)
+	if w == 2 && classifyWide(r) != wideCJK {
+		return 1
+	}
+	return w
+}
+
+// withEmojiWidth returns fn with emoji and wide symbols measured as n
+// columns, or fn itself for n == 0.
+func withEmojiWidth(fn WidthFunc, n int) WidthFunc {
+	if n == 0 {
+		return fn
+	}
+	return func(r rune) int {
+		if RuneWidth(r) == 2 && classifyWide(r) != wideCJK {
+			return n
+		}
+		return fn(r)
+	}
+}
+
 // StringWidthCustom calculates string width using a custom width function
 func StringWidthCustom(s string, widthFunc WidthFunc) int {
 	width := 0
@@ -322,6 +421,42 @@ func StringWidthBytesCustom(b []byte, widthFunc WidthFunc) int {
 	return width
 }
 
+// isPrintableASCII reports whether b consists only of printable ASCII bytes
+// (0x20–0x7E). Such text has no ANSI sequences, needs no UTF-8 decoding and,
+// under any sane WidthFunc, is exactly len(b) columns wide.
+func isPrintableASCII(b []byte) bool {
+	for _, c := range b {
+		if c < 0x20 || c > 0x7E {
+			return false
+		}
+	}
+	return true
+}
+
+// rowIsASCII reports whether every cell of row is printable ASCII.
+func rowIsASCII(row [][]byte) bool {
+	for _, cell := range row {
+		if !isPrintableASCII(cell) {
+			return false
+		}
+	}
+	return true
+}
+
+// unitASCII reports whether fn measures every printable ASCII rune as width
+// 1, which is what lets the ASCII fast path use len(cell) as the width.
+func unitASCII(fn WidthFunc) bool {
+	if fn == nil {
+		return false
+	}
+	for r := rune(0x20); r <= 0x7E; r++ {
+		if fn(r) != 1 {
+			return false
+		}
+	}
+	return true
+}
+
 // IsWideRune returns true if the rune has display width > 1
 func IsWideRune(r rune) bool {
 	return RuneWidth(r) > 1
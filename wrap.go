@@ -0,0 +1,139 @@
+// wrap.go
+
+package tables
+
+import (
+	"bytes"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SetWrap turns word wrapping on or off for column col. Values wider than
+// the column, whether capped by SetMaxWidth, locked with LockWidths or
+// narrowed to fit the terminal, continue on extra lines of the row instead
+// of being cut off. Lines break at spaces; a word wider than the column is
+// broken wherever it has to be. The other cells of the row stay on its first
+// line.
+//
+// Wrapping only affects the text output; the stored data and the exports
+// keep every value whole.
+//
+// Example:
+//
+//	t.SetMaxWidth(1, 16).SetWrap(1, true)
+//
+//	│ api    │ connection reset │
+//	│        │ by peer after    │
+//	│        │ 30s              │
+func (t *Table) SetWrap(col int, on bool) *Table {
+	if !t.checkColumn(col) {
+		return t
+	}
+	if !on {
+		delete(t.wrapCols, col)
+		return t
+	}
+	if t.wrapCols == nil {
+		t.wrapCols = make(map[int]bool)
+	}
+	t.wrapCols[col] = true
+	return t
+}
+
+// wrapRow splits row into the physical lines it takes when its wrapped
+// columns are too narrow for their cells. It returns nil when every cell
+// fits on one line.
+func (t *Table) wrapRow(row [][]byte, widths []int, rowIdx int, ascii bool, spans []int) [][][]byte {
+	var lines [][][]byte
+	for i := range widths {
+		if !t.wrapCols[i] || i >= len(row) || spans != nil && spans[i] == 0 {
+			continue
+		}
+		if rowIdx >= 0 && t.diff != nil && t.diff.covers(i) {
+			continue // the diff is worked out from the whole values
+		}
+		width := widths[i]
+		if spans != nil {
+			width = spanWidth(widths[i : i+spans[i]])
+		}
+
+		cell := row[i]
+		if rowIdx >= 0 && (t.timeZones != nil || t.columnKinds != nil) {
+			cell = t.timeCell(cell, i)
+		}
+		if t.cellWidth(cell, ascii) <= width {
+			continue
+		}
+
+		for k, frag := range t.wrapCell(cell, width, ascii) {
+			if k == len(lines) {
+				line := make([][]byte, len(row))
+				if k == 0 {
+					copy(line, row)
+				}
+				lines = append(lines, line)
+			}
+			lines[k][i] = frag
+		}
+	}
+	return lines
+}
+
+// wrapCell breaks cell into lines no wider than width, at spaces other than
+// no-break spaces where it can and inside words where it must.
+func (t *Table) wrapCell(cell []byte, width int, ascii bool) [][]byte {
+	if width < 1 {
+		return [][]byte{cell}
+	}
+
+	var lines [][]byte
+	var line []byte
+	lineWidth := 0
+	for _, word := range bytes.FieldsFunc(cell, breakable) {
+		w := t.cellWidth(word, ascii)
+		if line != nil && lineWidth+1+w <= width {
+			line = append(append(line, ' '), word...)
+			lineWidth += 1 + w
+			continue
+		}
+		if line != nil {
+			lines = append(lines, line)
+			line, lineWidth = nil, 0
+		}
+		for w > width {
+			n := t.fitPrefix(word, width, ascii)
+			lines = append(lines, word[:n:n])
+			word = word[n:]
+			w = t.cellWidth(word, ascii)
+		}
+		line = append([]byte(nil), word...)
+		lineWidth = w
+	}
+	if line != nil || lines == nil {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// breakable reports whether a line may break at r: any space but a no-break
+// space.
+func breakable(r rune) bool {
+	return unicode.IsSpace(r) && r != '\u00a0'
+}
+
+// fitPrefix returns the length in bytes of the longest prefix of word, at
+// least one rune, that is no wider than width.
+func (t *Table) fitPrefix(word []byte, width int, ascii bool) int {
+	if ascii && t.asciiUnit {
+		return width
+	}
+	n := 0
+	for n < len(word) {
+		_, size := utf8.DecodeRune(word[n:])
+		if n > 0 && t.cellWidth(word[:n+size], false) > width {
+			break
+		}
+		n += size
+	}
+	return n
+}